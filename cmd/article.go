@@ -1,21 +1,46 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"net/url"
 	"os"
+	"regexp"
+	"strings"
 
 	"github.com/alexhokl/scrape/scraper"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 type articleOptions struct {
-	format string
-	source string
-	url    string
+	format      string
+	source      string
+	url         string
+	oldestFirst bool
+	footnotes   bool
+	api         bool
+	title       string
+	language    string
+	revision    int64
+	sections    []string
+	excludes    []string
+	leadOnly    bool
+	maxLevel    int
+	ruby        string
+	audioDir    string
+	highlight   string
+	tocLinks    bool
+	tab         string
+	docsVersion string
 }
 
 var articleOpts articleOptions
 
+// wikipediaLanguagePattern matches the codes of language editions of
+// Wikipedia, e.g. en, ja or zh-yue.
+var wikipediaLanguagePattern = regexp.MustCompile(`^[a-z]{2,3}(-[a-z]+)*$`)
+
 // articleCmd represents the article command
 var articleCmd = &cobra.Command{
 	Use:               "article",
@@ -28,12 +53,27 @@ func init() {
 	rootCmd.AddCommand(articleCmd)
 
 	flags := articleCmd.PersistentFlags()
-	flags.StringVar(&articleOpts.format, "format", "markdown", "Output format (markdown)")
+	flags.StringVar(&articleOpts.format, "format", "markdown", "Output format (markdown, json)")
 	flags.StringVar(&articleOpts.source, "source", "", "Source type (e.g., guardian, etc)")
 	flags.StringVarP(&articleOpts.url, "url", "u", "", "URL of the article to scrape")
+	flags.StringVar(&articleOpts.title, "title", "", "Title of the article to scrape instead of URL (wikipedia)")
+	flags.BoolVar(&articleOpts.oldestFirst, "oldest-first", false, "List entries of live blogs in chronological order (guardian)")
+	flags.BoolVar(&articleOpts.footnotes, "footnotes", false, "Convert citations to markdown footnotes (wikipedia)")
+	flags.BoolVar(&articleOpts.api, "api", false, "Fetch the article through the MediaWiki API (wikipedia)")
+	flags.StringVar(&articleOpts.language, "language", "", "Language edition of the article specified by --title, e.g. ja; defaults to en (wikipedia)")
+	flags.Int64Var(&articleOpts.revision, "revision", 0, "ID of the revision of the article to fetch through the MediaWiki API (wikipedia)")
+	flags.StringSliceVar(&articleOpts.sections, "section", nil, "Headings of the sections to include in addition to the lead (wikipedia)")
+	flags.StringSliceVar(&articleOpts.excludes, "exclude-section", nil, "Headings of the sections to exclude (wikipedia)")
+	flags.BoolVar(&articleOpts.leadOnly, "lead-only", false, "Include the lead section only (wikipedia)")
+	flags.IntVar(&articleOpts.maxLevel, "max-heading-level", 0, "Exclude sections with headings deeper than the level, e.g. 2 (wikipedia)")
+	flags.StringVar(&articleOpts.ruby, "ruby", string(scraper.TofuguRubyParentheses), "Format of furigana: parentheses, html or anki (tofugu)")
+	flags.StringVar(&articleOpts.highlight, "highlight", string(scraper.TofuguHighlightBold), "Style of colour-coded text: bold, italic, html or none (tofugu)")
+	flags.BoolVar(&articleOpts.tocLinks, "toc-links", false, "Link items of tables of contents to the headings (tofugu)")
+	flags.StringVar(&articleOpts.tab, "tab", "", "Label of the tab of which instructions are rendered, e.g. macOS (tailscale)")
+	flags.StringVar(&articleOpts.docsVersion, "docs-version", "", "Version of the documentation to scrape instead of the version in the URL, e.g. v10.4 (grafana)")
+	flags.StringVar(&articleOpts.audioDir, "audio-dir", "", "Directory to download audio of example sentences to, e.g. audio (tofugu)")
 
 	articleCmd.MarkFlagRequired("source")
-	articleCmd.MarkFlagRequired("url")
 }
 
 func validateArticleOptions(_ *cobra.Command, _ []string) error {
@@ -41,6 +81,7 @@ func validateArticleOptions(_ *cobra.Command, _ []string) error {
 
 	switch opts.format {
 	case "markdown":
+	case "json":
 	default:
 		return fmt.Errorf("invalid format: %s", opts.format)
 	}
@@ -49,6 +90,7 @@ func validateArticleOptions(_ *cobra.Command, _ []string) error {
 	case "guardian":
 	case "microsoft":
 	case "go":
+	case "gopkg":
 	case "tofugu":
 	case "newyorktimes":
 	case "tailscale":
@@ -61,10 +103,83 @@ func validateArticleOptions(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("invalid source: %s", opts.source)
 	}
 
-	if opts.url == "" {
+	if !isValidTofuguHighlightStyle(opts.highlight) {
+		return fmt.Errorf("invalid highlight style: %s", opts.highlight)
+	}
+
+	// source specific options are rejected for other sources rather than
+	// ignored, so that a mistyped source does not give unfiltered output
+	for _, option := range []struct {
+		name   string
+		source string
+		set    bool
+	}{
+		{"oldest-first", "guardian", opts.oldestFirst},
+		{"footnotes", "wikipedia", opts.footnotes},
+		{"api", "wikipedia", opts.api},
+		{"section", "wikipedia", len(opts.sections) > 0},
+		{"exclude-section", "wikipedia", len(opts.excludes) > 0},
+		{"lead-only", "wikipedia", opts.leadOnly},
+		{"max-heading-level", "wikipedia", opts.maxLevel != 0},
+		{"ruby", "tofugu", opts.ruby != "" && opts.ruby != string(scraper.TofuguRubyParentheses)},
+		{"highlight", "tofugu", opts.highlight != "" && opts.highlight != string(scraper.TofuguHighlightBold)},
+		{"toc-links", "tofugu", opts.tocLinks},
+		{"audio-dir", "tofugu", opts.audioDir != ""},
+		{"tab", "tailscale", opts.tab != ""},
+		{"docs-version", "grafana", opts.docsVersion != ""},
+	} {
+		if option.set && opts.source != option.source {
+			return fmt.Errorf("%s is only supported by source %s", option.name, option.source)
+		}
+	}
+
+	if opts.title != "" || opts.revision != 0 {
+		if opts.source != "wikipedia" {
+			return fmt.Errorf("title and revision are only supported by source wikipedia")
+		}
+	}
+
+	if opts.language != "" {
+		if opts.source != "wikipedia" || opts.title == "" {
+			return fmt.Errorf("language is only supported with title of source wikipedia")
+		}
+		// the language becomes a part of the host name
+		if !wikipediaLanguagePattern.MatchString(opts.language) {
+			return fmt.Errorf("invalid language: %s", opts.language)
+		}
+	}
+
+	switch scraper.TofuguRubyFormat(opts.ruby) {
+	case "":
+	case scraper.TofuguRubyParentheses:
+	case scraper.TofuguRubyHTML:
+	case scraper.TofuguRubyAnki:
+	default:
+		return fmt.Errorf("invalid ruby format: %s", opts.ruby)
+	}
+
+	if opts.maxLevel < 0 || opts.maxLevel == 1 || opts.maxLevel > 6 {
+		return fmt.Errorf("max heading level must be between 2 and 6: %d", opts.maxLevel)
+	}
+
+	if opts.title != "" && opts.url != "" {
+		return fmt.Errorf("url and title cannot be specified together")
+	}
+
+	if opts.url == "" && opts.title == "" {
 		return fmt.Errorf("url is required")
 	}
 
+	if opts.format == "json" {
+		articleScraper, err := scraper.CreateArticleScraper(opts.source)
+		if err != nil {
+			return err
+		}
+		if _, ok := articleScraper.(scraper.DataScraper); !ok {
+			return fmt.Errorf("format json is not supported by source %s", opts.source)
+		}
+	}
+
 	return nil
 }
 
@@ -73,7 +188,13 @@ func scrapeArticle(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("error creating scraper: %w", err)
 	}
-	markdownStr, err := scraper.ScrapeArticle(articleOpts.url)
+	configureArticleScraper(scraper)
+
+	if articleOpts.format == "json" {
+		return scrapeArticleData(scraper)
+	}
+
+	markdownStr, err := scraper.ScrapeArticle(getArticleURL())
 	if err != nil {
 		return fmt.Errorf("error scraping article: %w", err)
 	}
@@ -81,3 +202,105 @@ func scrapeArticle(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// scrapeArticleData writes the article scraped as structured data to the
+// standard output in JSON.
+func scrapeArticleData(articleScraper scraper.ArticleScraper) error {
+	dataScraper, ok := articleScraper.(scraper.DataScraper)
+	if !ok {
+		return fmt.Errorf("format json is not supported by source %s", articleOpts.source)
+	}
+	data, err := dataScraper.ScrapeData(getArticleURL())
+	if err != nil {
+		return fmt.Errorf("error scraping article: %w", err)
+	}
+	encoder := json.NewEncoder(os.Stdout)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(data); err != nil {
+		return fmt.Errorf("error encoding article: %w", err)
+	}
+
+	return nil
+}
+
+// getArticleURL returns the URL of the article to scrape, which is the
+// Wikipedia page of the title when a title is specified.
+func getArticleURL() string {
+	if articleOpts.title == "" {
+		return articleOpts.url
+	}
+	language := articleOpts.language
+	if language == "" {
+		language = "en"
+	}
+	title := strings.ReplaceAll(strings.TrimSpace(articleOpts.title), " ", "_")
+	return fmt.Sprintf("https://%s.wikipedia.org/wiki/%s", language, url.PathEscape(title))
+}
+
+// isValidTofuguHighlightStyle returns true if the style is empty or one of
+// the styles of colour-coded text.
+func isValidTofuguHighlightStyle(style string) bool {
+	switch scraper.TofuguHighlightStyle(style) {
+	case "":
+	case scraper.TofuguHighlightBold:
+	case scraper.TofuguHighlightItalic:
+	case scraper.TofuguHighlightHTML:
+	case scraper.TofuguHighlightNone:
+	default:
+		return false
+	}
+	return true
+}
+
+// getTofuguHighlights returns the styles of colour-coded text per colour
+// configured in tofugu.highlights, e.g.
+//
+//	tofugu:
+//	  highlights:
+//	    red: bold
+//	    blue: italic
+//
+// Invalid styles are ignored.
+func getTofuguHighlights() map[string]scraper.TofuguHighlightStyle {
+	configured := viper.GetStringMapString("tofugu.highlights")
+	if len(configured) == 0 {
+		return nil
+	}
+	highlights := make(map[string]scraper.TofuguHighlightStyle, len(configured))
+	for color, style := range configured {
+		if style == "" || !isValidTofuguHighlightStyle(style) {
+			continue
+		}
+		highlights[strings.ToLower(color)] = scraper.TofuguHighlightStyle(style)
+	}
+	return highlights
+}
+
+// configureArticleScraper applies the source specific options and
+// configurations to the scraper.
+func configureArticleScraper(articleScraper scraper.ArticleScraper) {
+	switch s := articleScraper.(type) {
+	case *scraper.GuardianScraper:
+		s.OldestFirst = articleOpts.oldestFirst
+	case *scraper.TofuguScraper:
+		s.Ruby = scraper.TofuguRubyFormat(articleOpts.ruby)
+		s.AudioDir = articleOpts.audioDir
+		s.Highlight = scraper.TofuguHighlightStyle(articleOpts.highlight)
+		s.Highlights = getTofuguHighlights()
+		s.TOCLinks = articleOpts.tocLinks
+	case *scraper.TailscaleScraper:
+		s.Tab = articleOpts.tab
+	case *scraper.GrafanaScraper:
+		s.Version = articleOpts.docsVersion
+	case *scraper.WikipediaScraper:
+		s.SkipSections = viper.GetStringMapStringSlice("wikipedia.skip_sections")
+		s.Footnotes = articleOpts.footnotes
+		// articles specified by title are always fetched through the API
+		s.API = articleOpts.api || articleOpts.title != ""
+		s.Revision = articleOpts.revision
+		s.Sections = articleOpts.sections
+		s.ExcludeSections = articleOpts.excludes
+		s.LeadOnly = articleOpts.leadOnly
+		s.MaxHeadingLevel = articleOpts.maxLevel
+	}
+}
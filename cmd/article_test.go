@@ -4,7 +4,9 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alexhokl/scrape/scraper"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 )
 
 func TestValidateArticleOptions_ValidGuardianSource(t *testing.T) {
@@ -61,6 +63,24 @@ func TestValidateArticleOptions_ValidGoSource(t *testing.T) {
 	}
 }
 
+func TestValidateArticleOptions_ValidGoPackageSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "gopkg",
+		url:    "https://pkg.go.dev/net/http",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for valid gopkg source, got: %v", err)
+	}
+}
+
 func TestValidateArticleOptions_ValidTofuguSource(t *testing.T) {
 	// Save original opts and restore after test
 	originalOpts := articleOpts
@@ -133,7 +153,7 @@ func TestValidateArticleOptions_InvalidFormat(t *testing.T) {
 	defer func() { articleOpts = originalOpts }()
 
 	articleOpts = articleOptions{
-		format: "json",
+		format: "xml",
 		source: "guardian",
 		url:    "https://www.theguardian.com/some-article",
 	}
@@ -148,11 +168,69 @@ func TestValidateArticleOptions_InvalidFormat(t *testing.T) {
 		t.Errorf("expected error message to contain 'invalid format', got: %v", err)
 	}
 
-	if !strings.Contains(err.Error(), "json") {
+	if !strings.Contains(err.Error(), "xml") {
 		t.Errorf("expected error message to contain the invalid format name, got: %v", err)
 	}
 }
 
+func TestValidateArticleOptions_JSONFormat(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "json",
+		source: "wikipedia",
+		url:    "https://en.wikipedia.org/wiki/Merkle_tree",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for json format of wikipedia, got: %v", err)
+	}
+}
+
+func TestValidateArticleOptions_JSONFormatOllama(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "json",
+		source: "ollama",
+		url:    "https://ollama.com/library/llama3.2",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for json format of ollama, got: %v", err)
+	}
+}
+
+func TestValidateArticleOptions_JSONFormatUnsupportedSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "json",
+		source: "guardian",
+		url:    "https://www.theguardian.com/some-article",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for json format of guardian, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "not supported") {
+		t.Errorf("expected error message to contain 'not supported', got: %v", err)
+	}
+}
+
 func TestValidateArticleOptions_EmptyFormat(t *testing.T) {
 	// Save original opts and restore after test
 	originalOpts := articleOpts
@@ -319,3 +397,521 @@ func TestArticleOptions_StructFields(t *testing.T) {
 		t.Errorf("expected url to be 'https://www.theguardian.com/test', got %q", opts.url)
 	}
 }
+
+func TestConfigureArticleScraper_GuardianOldestFirst(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:      "markdown",
+		source:      "guardian",
+		url:         "https://www.theguardian.com/some-live-blog",
+		oldestFirst: true,
+	}
+
+	guardian := &scraper.GuardianScraper{}
+	configureArticleScraper(guardian)
+
+	if !guardian.OldestFirst {
+		t.Error("expected OldestFirst to be set on guardian scraper")
+	}
+}
+
+func TestConfigureArticleScraper_WikipediaSkipSections(t *testing.T) {
+	viper.Set("wikipedia.skip_sections", map[string][]string{
+		"ja": {"脚注", "出典"},
+	})
+	defer viper.Set("wikipedia.skip_sections", nil)
+
+	wikipedia := &scraper.WikipediaScraper{}
+	configureArticleScraper(wikipedia)
+
+	sections := wikipedia.SkipSections["ja"]
+	if len(sections) != 2 || sections[0] != "脚注" || sections[1] != "出典" {
+		t.Errorf("expected skip sections from configuration, got %v", wikipedia.SkipSections)
+	}
+}
+
+func TestConfigureArticleScraper_WikipediaFootnotes(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:    "markdown",
+		source:    "wikipedia",
+		url:       "https://en.wikipedia.org/wiki/Merkle_tree",
+		footnotes: true,
+	}
+
+	wikipedia := &scraper.WikipediaScraper{}
+	configureArticleScraper(wikipedia)
+
+	if !wikipedia.Footnotes {
+		t.Error("expected Footnotes to be set on wikipedia scraper")
+	}
+}
+
+func TestValidateArticleOptions_WikipediaTitle(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "wikipedia",
+		title:    "Go (programming language)",
+		language: "en",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for title without url, got: %v", err)
+	}
+}
+
+func TestValidateArticleOptions_TitleWithURL(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "wikipedia",
+		url:    "https://en.wikipedia.org/wiki/Go_(programming_language)",
+		title:  "Go (programming language)",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for title with url, got nil")
+	}
+}
+
+func TestValidateArticleOptions_TitleUnsupportedSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "guardian",
+		title:  "Some article",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for title with guardian source, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "wikipedia") {
+		t.Errorf("expected error message to mention wikipedia, got: %v", err)
+	}
+}
+
+func TestValidateArticleOptions_SourceSpecificOptions(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	tests := []struct {
+		name   string
+		opts   articleOptions
+		source string
+	}{
+		{"oldest-first", articleOptions{oldestFirst: true}, "guardian"},
+		{"footnotes", articleOptions{footnotes: true}, "wikipedia"},
+		{"section", articleOptions{sections: []string{"History"}}, "wikipedia"},
+		{"exclude-section", articleOptions{excludes: []string{"History"}}, "wikipedia"},
+		{"lead-only", articleOptions{leadOnly: true}, "wikipedia"},
+		{"max-heading-level", articleOptions{maxLevel: 2}, "wikipedia"},
+		{"ruby", articleOptions{ruby: "anki"}, "tofugu"},
+		{"toc-links", articleOptions{tocLinks: true}, "tofugu"},
+		{"tab", articleOptions{tab: "macOS"}, "tailscale"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articleOpts = tt.opts
+			articleOpts.format = "markdown"
+			articleOpts.url = "https://example.com/article"
+
+			articleOpts.source = tt.source
+			if err := validateArticleOptions(&cobra.Command{}, []string{}); err != nil {
+				t.Errorf("expected no error for %s with source %s, got: %v", tt.name, tt.source, err)
+			}
+
+			articleOpts.source = "cloudflare"
+			err := validateArticleOptions(&cobra.Command{}, []string{})
+			if err == nil {
+				t.Fatalf("expected error for %s with source cloudflare, got nil", tt.name)
+			}
+			if !strings.Contains(err.Error(), tt.name) {
+				t.Errorf("expected error message to mention %s, got: %v", tt.name, err)
+			}
+		})
+	}
+}
+
+func TestValidateArticleOptions_Language(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	tests := []struct {
+		name    string
+		opts    articleOptions
+		wantErr bool
+	}{
+		{"with title", articleOptions{source: "wikipedia", title: "ハッシュ木", language: "ja"}, false},
+		{"with subtag", articleOptions{source: "wikipedia", title: "Go", language: "zh-yue"}, false},
+		{"with url", articleOptions{source: "wikipedia", url: "https://ja.wikipedia.org/wiki/Go", language: "ja"}, true},
+		{"other source", articleOptions{source: "guardian", url: "https://www.theguardian.com/some-article", language: "ja"}, true},
+		{"invalid code", articleOptions{source: "wikipedia", title: "Go", language: "evil.com/x"}, true},
+		{"upper case", articleOptions{source: "wikipedia", title: "Go", language: "EN"}, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			articleOpts = tt.opts
+			articleOpts.format = "markdown"
+
+			err := validateArticleOptions(&cobra.Command{}, []string{})
+			if tt.wantErr && err == nil {
+				t.Error("expected error, got nil")
+			}
+			if !tt.wantErr && err != nil {
+				t.Errorf("expected no error, got: %v", err)
+			}
+		})
+	}
+}
+
+func TestGetArticleURL(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	tests := []struct {
+		opts articleOptions
+		want string
+	}{
+		{
+			articleOptions{url: "https://en.wikipedia.org/wiki/Merkle_tree"},
+			"https://en.wikipedia.org/wiki/Merkle_tree",
+		},
+		{
+			articleOptions{title: "Go (programming language)", language: "en"},
+			"https://en.wikipedia.org/wiki/Go_%28programming_language%29",
+		},
+		{
+			articleOptions{title: "Merkle tree"},
+			"https://en.wikipedia.org/wiki/Merkle_tree",
+		},
+		{
+			articleOptions{title: "ハッシュ木", language: "ja"},
+			"https://ja.wikipedia.org/wiki/%E3%83%8F%E3%83%83%E3%82%B7%E3%83%A5%E6%9C%A8",
+		},
+	}
+
+	for _, tt := range tests {
+		articleOpts = tt.opts
+		got := getArticleURL()
+		if got != tt.want {
+			t.Errorf("getArticleURL() = %q, want %q", got, tt.want)
+		}
+	}
+}
+
+func TestConfigureArticleScraper_WikipediaAPI(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "wikipedia",
+		title:    "Go (programming language)",
+		language: "en",
+		revision: 1234,
+	}
+
+	wikipedia := &scraper.WikipediaScraper{}
+	configureArticleScraper(wikipedia)
+
+	if !wikipedia.API {
+		t.Error("expected API to be set when title is specified")
+	}
+	if wikipedia.Revision != 1234 {
+		t.Errorf("expected Revision to be 1234, got %d", wikipedia.Revision)
+	}
+}
+
+func TestValidateArticleOptions_InvalidMaxHeadingLevel(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	for _, level := range []int{-1, 1, 7} {
+		articleOpts = articleOptions{
+			format:   "markdown",
+			source:   "wikipedia",
+			url:      "https://en.wikipedia.org/wiki/Merkle_tree",
+			maxLevel: level,
+		}
+
+		err := validateArticleOptions(&cobra.Command{}, []string{})
+
+		if err == nil {
+			t.Errorf("expected error for max heading level %d, got nil", level)
+		}
+	}
+}
+
+func TestConfigureArticleScraper_WikipediaSections(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "wikipedia",
+		url:      "https://en.wikipedia.org/wiki/Merkle_tree",
+		sections: []string{"History"},
+		excludes: []string{"Design"},
+		leadOnly: true,
+		maxLevel: 3,
+	}
+
+	wikipedia := &scraper.WikipediaScraper{}
+	configureArticleScraper(wikipedia)
+
+	if len(wikipedia.Sections) != 1 || wikipedia.Sections[0] != "History" {
+		t.Errorf("expected Sections to be set, got %v", wikipedia.Sections)
+	}
+	if len(wikipedia.ExcludeSections) != 1 || wikipedia.ExcludeSections[0] != "Design" {
+		t.Errorf("expected ExcludeSections to be set, got %v", wikipedia.ExcludeSections)
+	}
+	if !wikipedia.LeadOnly {
+		t.Error("expected LeadOnly to be set")
+	}
+	if wikipedia.MaxHeadingLevel != 3 {
+		t.Errorf("expected MaxHeadingLevel to be 3, got %d", wikipedia.MaxHeadingLevel)
+	}
+}
+
+func TestValidateArticleOptions_InvalidRubyFormat(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "tofugu",
+		url:    "https://www.tofugu.com/japanese-grammar/monono/",
+		ruby:   "romaji",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for invalid ruby format, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "romaji") {
+		t.Errorf("expected error message to contain the invalid ruby format, got: %v", err)
+	}
+}
+
+func TestConfigureArticleScraper_TofuguRuby(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "tofugu",
+		url:    "https://www.tofugu.com/japanese-grammar/monono/",
+		ruby:   "anki",
+	}
+
+	tofugu := &scraper.TofuguScraper{}
+	configureArticleScraper(tofugu)
+
+	if tofugu.Ruby != scraper.TofuguRubyAnki {
+		t.Errorf("expected Ruby to be anki, got %q", tofugu.Ruby)
+	}
+}
+
+func TestConfigureArticleScraper_TofuguTOCLinks(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "tofugu",
+		url:      "https://www.tofugu.com/japanese-grammar/monono/",
+		tocLinks: true,
+	}
+
+	tofugu := &scraper.TofuguScraper{}
+	configureArticleScraper(tofugu)
+
+	if !tofugu.TOCLinks {
+		t.Error("expected TOCLinks to be set on tofugu scraper")
+	}
+}
+
+func TestConfigureArticleScraper_TofuguAudioDir(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "tofugu",
+		url:      "https://www.tofugu.com/japanese-grammar/monono/",
+		audioDir: "audio",
+	}
+
+	tofugu := &scraper.TofuguScraper{}
+	configureArticleScraper(tofugu)
+
+	if tofugu.AudioDir != "audio" {
+		t.Errorf("expected AudioDir to be audio, got %q", tofugu.AudioDir)
+	}
+}
+
+func TestValidateArticleOptions_AudioDirRequiresTofugu(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:   "markdown",
+		source:   "guardian",
+		url:      "https://www.theguardian.com/some-article",
+		audioDir: "audio",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for audio-dir with source other than tofugu, got nil")
+	}
+}
+
+func TestValidateArticleOptions_InvalidHighlightStyle(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:    "markdown",
+		source:    "tofugu",
+		url:       "https://www.tofugu.com/japanese-grammar/monono/",
+		highlight: "underline",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for invalid highlight style, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "underline") {
+		t.Errorf("expected error message to contain the invalid highlight style, got: %v", err)
+	}
+}
+
+func TestConfigureArticleScraper_TofuguHighlights(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:    "markdown",
+		source:    "tofugu",
+		url:       "https://www.tofugu.com/japanese-grammar/monono/",
+		highlight: "italic",
+	}
+	viper.Set("tofugu.highlights", map[string]string{
+		"red":  "html",
+		"blue": "strikethrough",
+	})
+	defer viper.Set("tofugu.highlights", nil)
+
+	tofugu := &scraper.TofuguScraper{}
+	configureArticleScraper(tofugu)
+
+	if tofugu.Highlight != scraper.TofuguHighlightItalic {
+		t.Errorf("expected Highlight to be italic, got %q", tofugu.Highlight)
+	}
+	if len(tofugu.Highlights) != 1 || tofugu.Highlights["red"] != scraper.TofuguHighlightHTML {
+		t.Errorf("expected valid highlights from configuration, got %v", tofugu.Highlights)
+	}
+}
+
+func TestConfigureArticleScraper_TailscaleTab(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format: "markdown",
+		source: "tailscale",
+		url:    "https://tailscale.com/kb/1017/install",
+		tab:    "macOS",
+	}
+
+	tailscale := &scraper.TailscaleScraper{}
+	configureArticleScraper(tailscale)
+
+	if tailscale.Tab != "macOS" {
+		t.Errorf("expected Tab to be macOS, got %q", tailscale.Tab)
+	}
+}
+
+func TestValidateArticleOptions_DocsVersionRequiresGrafana(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:      "markdown",
+		source:      "tailscale",
+		url:         "https://tailscale.com/kb/1017/install",
+		docsVersion: "v10.4",
+	}
+
+	err := validateArticleOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for docs-version with source other than grafana, got nil")
+	}
+}
+
+func TestConfigureArticleScraper_GrafanaDocsVersion(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := articleOpts
+	defer func() { articleOpts = originalOpts }()
+
+	articleOpts = articleOptions{
+		format:      "markdown",
+		source:      "grafana",
+		url:         "https://grafana.com/docs/grafana/latest/alerting/",
+		docsVersion: "v10.4",
+	}
+
+	grafana := &scraper.GrafanaScraper{}
+	configureArticleScraper(grafana)
+
+	if grafana.Version != "v10.4" {
+		t.Errorf("expected Version to be v10.4, got %q", grafana.Version)
+	}
+}
@@ -58,6 +58,23 @@ func TestValidateFilenameOptions_ValidGoSource(t *testing.T) {
 	}
 }
 
+func TestValidateFilenameOptions_ValidGoPackageSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := filenameOpts
+	defer func() { filenameOpts = originalOpts }()
+
+	filenameOpts = filenameOptions{
+		source: "gopkg",
+		url:    "https://pkg.go.dev/net/http",
+	}
+
+	err := validateFilenameOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for valid gopkg source, got: %v", err)
+	}
+}
+
 func TestValidateFilenameOptions_ValidTofuguSource(t *testing.T) {
 	// Save original opts and restore after test
 	originalOpts := filenameOpts
@@ -0,0 +1,73 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/alexhokl/scrape/scraper"
+	"github.com/spf13/cobra"
+)
+
+type flashcardsOptions struct {
+	format   string
+	url      string
+	audio    bool
+	audioDir string
+}
+
+var flashcardsOpts flashcardsOptions
+
+// flashcardsCmd represents the flashcards command
+var flashcardsCmd = &cobra.Command{
+	Use:               "flashcards",
+	Short:             "Export example sentences and definitions of a Tofugu article as Anki flashcards",
+	PersistentPreRunE: validateFlashcardsOptions,
+	RunE:              scrapeFlashcards,
+}
+
+func init() {
+	rootCmd.AddCommand(flashcardsCmd)
+
+	flags := flashcardsCmd.PersistentFlags()
+	flags.StringVar(&flashcardsOpts.format, "format", "tsv", "Output format (tsv, csv)")
+	flags.StringVarP(&flashcardsOpts.url, "url", "u", "", "URL of the Tofugu article to scrape")
+	flags.BoolVar(&flashcardsOpts.audio, "audio", false, "Add a column of references to the audio of example sentences, which are downloaded with --audio-dir")
+	flags.StringVar(&flashcardsOpts.audioDir, "audio-dir", "", "Directory to download audio of example sentences to, e.g. collection.media of Anki; implies --audio")
+
+	flashcardsCmd.MarkFlagRequired("url")
+}
+
+func validateFlashcardsOptions(_ *cobra.Command, _ []string) error {
+	opts := &flashcardsOpts
+
+	switch opts.format {
+	case "tsv":
+	case "csv":
+	default:
+		return fmt.Errorf("invalid format: %s", opts.format)
+	}
+
+	if opts.url == "" {
+		return fmt.Errorf("url is required")
+	}
+
+	return nil
+}
+
+func scrapeFlashcards(_ *cobra.Command, _ []string) error {
+	tofugu := &scraper.TofuguScraper{AudioDir: flashcardsOpts.audioDir}
+	deck, err := tofugu.ScrapeFlashcards(flashcardsOpts.url)
+	if err != nil {
+		return fmt.Errorf("error scraping flashcards: %w", err)
+	}
+
+	comma := '\t'
+	if flashcardsOpts.format == "csv" {
+		comma = ','
+	}
+	if err := deck.Write(os.Stdout, comma, flashcardsOpts.audio || flashcardsOpts.audioDir != ""); err != nil {
+		return fmt.Errorf("error writing flashcards: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,64 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+func TestValidateFlashcardsOptions_ValidFormats(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := flashcardsOpts
+	defer func() { flashcardsOpts = originalOpts }()
+
+	for _, format := range []string{"tsv", "csv"} {
+		flashcardsOpts = flashcardsOptions{
+			format: format,
+			url:    "https://www.tofugu.com/japanese-grammar/monono/",
+		}
+
+		err := validateFlashcardsOptions(&cobra.Command{}, []string{})
+
+		if err != nil {
+			t.Errorf("expected no error for format %s, got: %v", format, err)
+		}
+	}
+}
+
+func TestValidateFlashcardsOptions_InvalidFormat(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := flashcardsOpts
+	defer func() { flashcardsOpts = originalOpts }()
+
+	flashcardsOpts = flashcardsOptions{
+		format: "apkg",
+		url:    "https://www.tofugu.com/japanese-grammar/monono/",
+	}
+
+	err := validateFlashcardsOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for invalid format, got nil")
+	}
+
+	if !strings.Contains(err.Error(), "apkg") {
+		t.Errorf("expected error message to contain the invalid format, got: %v", err)
+	}
+}
+
+func TestValidateFlashcardsOptions_MissingURL(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := flashcardsOpts
+	defer func() { flashcardsOpts = originalOpts }()
+
+	flashcardsOpts = flashcardsOptions{
+		format: "tsv",
+	}
+
+	err := validateFlashcardsOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for missing url, got nil")
+	}
+}
@@ -11,6 +11,7 @@ import (
 type linksOptions struct {
 	source string
 	url    string
+	depth  int
 }
 
 var linksOpts linksOptions
@@ -29,6 +30,7 @@ func init() {
 	flags := linksCmd.PersistentFlags()
 	flags.StringVarP(&linksOpts.url, "url", "u", "", "URL of the links to scrape")
 	flags.StringVar(&linksOpts.source, "source", "", "Source type (e.g., guardian, etc)")
+	flags.IntVar(&linksOpts.depth, "depth", 0, "Levels of subcategories to include for category pages (wikipedia)")
 
 	linksCmd.MarkFlagRequired("source")
 	linksCmd.MarkFlagRequired("url")
@@ -40,6 +42,8 @@ func validateLinksOptions(_ *cobra.Command, _ []string) error {
 	switch opts.source {
 	case "guardian":
 		break
+	case "wikipedia":
+		break
 
 	default:
 		return fmt.Errorf("invalid source: %s", opts.source)
@@ -49,6 +53,14 @@ func validateLinksOptions(_ *cobra.Command, _ []string) error {
 		return fmt.Errorf("url is required")
 	}
 
+	if opts.depth != 0 && opts.source != "wikipedia" {
+		return fmt.Errorf("depth is only supported by source wikipedia")
+	}
+
+	if opts.depth < 0 {
+		return fmt.Errorf("depth cannot be negative: %d", opts.depth)
+	}
+
 	return nil
 }
 
@@ -57,6 +69,7 @@ func scrapeLinks(_ *cobra.Command, args []string) error {
 	if err != nil {
 		return fmt.Errorf("error creating scraper: %w", err)
 	}
+	configureLinkScraper(scraper)
 	links, err := scraper.ScrapeLinks(linksOpts.url)
 	if err != nil {
 		return fmt.Errorf("error scraping links: %w", err)
@@ -68,3 +81,11 @@ func scrapeLinks(_ *cobra.Command, args []string) error {
 
 	return nil
 }
+
+// configureLinkScraper applies the source specific options to the scraper.
+func configureLinkScraper(linkScraper scraper.LinkScraper) {
+	switch s := linkScraper.(type) {
+	case *scraper.WikipediaScraper:
+		s.CategoryDepth = linksOpts.depth
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"strings"
 	"testing"
 
+	"github.com/alexhokl/scrape/scraper"
 	"github.com/spf13/cobra"
 )
 
@@ -154,3 +155,79 @@ func TestLinksOptions_StructFields(t *testing.T) {
 		t.Errorf("expected url to be 'https://www.theguardian.com/test', got %q", opts.url)
 	}
 }
+
+func TestValidateLinksOptions_ValidWikipediaSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := linksOpts
+	defer func() { linksOpts = originalOpts }()
+
+	linksOpts = linksOptions{
+		source: "wikipedia",
+		url:    "https://en.wikipedia.org/wiki/Category:Hash_functions",
+		depth:  2,
+	}
+
+	err := validateLinksOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for valid wikipedia source, got: %v", err)
+	}
+}
+
+func TestValidateLinksOptions_NegativeDepth(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := linksOpts
+	defer func() { linksOpts = originalOpts }()
+
+	linksOpts = linksOptions{
+		source: "wikipedia",
+		url:    "https://en.wikipedia.org/wiki/Category:Hash_functions",
+		depth:  -1,
+	}
+
+	err := validateLinksOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for negative depth, got nil")
+	}
+}
+
+func TestValidateLinksOptions_DepthWithOtherSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := linksOpts
+	defer func() { linksOpts = originalOpts }()
+
+	linksOpts = linksOptions{
+		source: "guardian",
+		url:    "https://www.theguardian.com/test",
+		depth:  2,
+	}
+
+	err := validateLinksOptions(&cobra.Command{}, []string{})
+
+	if err == nil {
+		t.Fatal("expected error for depth with guardian source, got nil")
+	}
+	if !strings.Contains(err.Error(), "depth is only supported by source wikipedia") {
+		t.Errorf("expected error message to contain 'depth is only supported by source wikipedia', got: %v", err)
+	}
+}
+
+func TestConfigureLinkScraper_WikipediaDepth(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := linksOpts
+	defer func() { linksOpts = originalOpts }()
+
+	linksOpts = linksOptions{
+		source: "wikipedia",
+		url:    "https://en.wikipedia.org/wiki/Category:Hash_functions",
+		depth:  2,
+	}
+
+	wikipedia := &scraper.WikipediaScraper{}
+	configureLinkScraper(wikipedia)
+
+	if wikipedia.CategoryDepth != 2 {
+		t.Errorf("expected CategoryDepth to be 2, got %d", wikipedia.CategoryDepth)
+	}
+}
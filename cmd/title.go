@@ -41,6 +41,7 @@ func validateTitleOptions(_ *cobra.Command, _ []string) error {
 	case "guardian":
 	case "microsoft":
 	case "go":
+	case "gopkg":
 	case "tofugu":
 	case "cloudflare":
 	case "wikipedia":
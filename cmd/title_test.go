@@ -58,6 +58,23 @@ func TestValidateTitleOptions_ValidGoSource(t *testing.T) {
 	}
 }
 
+func TestValidateTitleOptions_ValidGoPackageSource(t *testing.T) {
+	// Save original opts and restore after test
+	originalOpts := titleOpts
+	defer func() { titleOpts = originalOpts }()
+
+	titleOpts = titleOptions{
+		source: "gopkg",
+		url:    "https://pkg.go.dev/net/http",
+	}
+
+	err := validateTitleOptions(&cobra.Command{}, []string{})
+
+	if err != nil {
+		t.Errorf("expected no error for valid gopkg source, got: %v", err)
+	}
+}
+
 func TestValidateTitleOptions_ValidTofuguSource(t *testing.T) {
 	// Save original opts and restore after test
 	originalOpts := titleOpts
@@ -2,6 +2,8 @@ package scraper
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -18,6 +20,47 @@ func (c *CloudflareScraper) ScrapeArticle(url string) (string, error) {
 	collector := colly.NewCollector()
 
 	var markdown string
+	metadata := frontMatter{}
+	var date, publishedTime string
+	authors := make([]string, 0)
+	tags := make([]string, 0)
+
+	// publish date
+	collector.OnHTML("meta[property='article:published_time']", func(e *colly.HTMLElement) {
+		publishedTime = e.Attr("content")
+	})
+	collector.OnHTML("article.post-full [data-iso-date]", func(e *colly.HTMLElement) {
+		date = e.Attr("data-iso-date")
+	})
+
+	// authors
+	collector.OnHTML("article.post-full a[href*='/author/']", func(e *colly.HTMLElement) {
+		// links to authors in the post are not bylines
+		if e.DOM.Closest("div.post-content").Length() > 0 {
+			return
+		}
+		author := strings.Join(strings.Fields(e.Text), " ")
+		if author != "" && !slices.Contains(authors, author) {
+			authors = append(authors, author)
+		}
+	})
+
+	// tags
+	collector.OnHTML("meta[property='article:tag']", func(e *colly.HTMLElement) {
+		tag := strings.TrimSpace(e.Attr("content"))
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	})
+	collector.OnHTML("article.post-full a[href*='/tag/']", func(e *colly.HTMLElement) {
+		if e.DOM.Closest("div.post-content").Length() > 0 {
+			return
+		}
+		tag := strings.Join(strings.Fields(e.Text), " ")
+		if tag != "" && !slices.Contains(tags, tag) {
+			tags = append(tags, tag)
+		}
+	})
 
 	// title
 	collector.OnHTML("article.post-full > h1", func(e *colly.HTMLElement) {
@@ -39,7 +82,15 @@ func (c *CloudflareScraper) ScrapeArticle(url string) (string, error) {
 		return "", err
 	}
 
-	return markdown, nil
+	// the machine readable date is preferred
+	if publishedTime != "" {
+		date = publishedTime
+	}
+	metadata.set("date", date)
+	metadata.setList("authors", authors)
+	metadata.setList("tags", tags)
+
+	return metadata.String() + markdown, nil
 }
 
 func (c *CloudflareScraper) ScrapeTitle(url string) (string, error) {
@@ -85,6 +136,11 @@ func parseCloudflareContent(e *colly.HTMLElement) string {
 						builder.WriteString(fmt.Sprintf("#### %s\n\n", strings.TrimSpace(heading.Text)))
 					}
 				})
+			} else {
+				// tables are wrapped to scroll horizontally
+				child.ForEach("table", func(_ int, table *colly.HTMLElement) {
+					builder.WriteString(renderMarkdownTable(table, parseCloudflareInline))
+				})
 			}
 		case "p":
 			text := strings.TrimSpace(parseCloudflareInline(child))
@@ -97,27 +153,114 @@ func parseCloudflareContent(e *colly.HTMLElement) string {
 			builder.WriteString(parseCloudflareList(child, true))
 		case "pre":
 			builder.WriteString(parseCloudflareCodeBlock(child))
+		case "table":
+			builder.WriteString(renderMarkdownTable(child, parseCloudflareInline))
+		case "iframe":
+			builder.WriteString(parseCloudflareEmbed(child))
 		case "blockquote":
+			if child.DOM.HasClass("twitter-tweet") {
+				builder.WriteString(parseCloudflareEmbed(child))
+				break
+			}
 			text := strings.TrimSpace(child.Text)
 			if text != "" {
 				builder.WriteString(fmt.Sprintf("> %s\n\n", text))
 			}
 		case "figure":
-			if child.DOM.HasClass("kg-image-card") {
-				child.ForEach("img", func(_ int, img *colly.HTMLElement) {
-					src := img.Attr("src")
-					alt := img.Attr("alt")
-					if src != "" {
-						builder.WriteString(fmt.Sprintf("![%s](%s)\n\n", alt, src))
-					}
-				})
-			}
+			builder.WriteString(parseCloudflareFigure(child))
 		}
 	})
 
 	return builder.String()
 }
 
+// parseCloudflareFigure renders a <figure> element, which holds an image,
+// a table or an embedded tweet or video, followed by its caption in italics.
+func parseCloudflareFigure(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+	switch {
+	case e.DOM.Find("table").Length() > 0:
+		e.ForEach("table", func(_ int, table *colly.HTMLElement) {
+			builder.WriteString(renderMarkdownTable(table, parseCloudflareInline))
+		})
+	case e.DOM.Find("iframe, blockquote.twitter-tweet").Length() > 0:
+		e.ForEach("iframe, blockquote.twitter-tweet", func(_ int, embed *colly.HTMLElement) {
+			builder.WriteString(parseCloudflareEmbed(embed))
+		})
+	default:
+		e.ForEach("img", func(_ int, img *colly.HTMLElement) {
+			src := img.Attr("src")
+			alt := img.Attr("alt")
+			if src != "" {
+				builder.WriteString(fmt.Sprintf("![%s](%s)\n\n", alt, img.Request.AbsoluteURL(src)))
+			}
+		})
+	}
+	if builder.Len() == 0 {
+		return ""
+	}
+
+	e.ForEach("figcaption", func(_ int, figcaption *colly.HTMLElement) {
+		caption := strings.Join(strings.Fields(parseCloudflareInline(figcaption)), " ")
+		if caption != "" {
+			builder.WriteString(fmt.Sprintf("*%s*\n\n", caption))
+		}
+	})
+	return builder.String()
+}
+
+// parseCloudflareEmbed renders an embedded tweet or video as a link labelled
+// with the kind of the embed. The text of a tweet is quoted above the link.
+func parseCloudflareEmbed(e *colly.HTMLElement) string {
+	if e.Name == "blockquote" {
+		// the last link of an embedded tweet is the link to the tweet
+		link := e.DOM.Find("a[href]").Last().AttrOr("href", "")
+		text := strings.Join(strings.Fields(e.ChildText("p")), " ")
+		builder := strings.Builder{}
+		if text != "" {
+			builder.WriteString(fmt.Sprintf("> %s\n\n", text))
+		}
+		if link != "" {
+			builder.WriteString(fmt.Sprintf("[Tweet](%s)\n\n", e.Request.AbsoluteURL(link)))
+		}
+		return builder.String()
+	}
+
+	src := e.Attr("src")
+	if src == "" {
+		return ""
+	}
+	src = e.Request.AbsoluteURL(src)
+	label := "Embedded content"
+	if id := getYouTubeVideoID(src); id != "" {
+		label = "YouTube video"
+		src = "https://www.youtube.com/watch?v=" + id
+	}
+	if title := strings.TrimSpace(e.Attr("title")); title != "" {
+		label = fmt.Sprintf("%s: %s", label, title)
+	}
+	return fmt.Sprintf("[%s](%s)\n\n", label, src)
+}
+
+// getYouTubeVideoID returns the ID of the video of a YouTube embed URL such
+// as https://www.youtube.com/embed/ID?feature=oembed. It returns an empty
+// string for other URLs.
+func getYouTubeVideoID(src string) string {
+	parsed, err := url.Parse(src)
+	if err != nil {
+		return ""
+	}
+	host := strings.TrimPrefix(parsed.Hostname(), "www.")
+	if host != "youtube.com" && host != "youtube-nocookie.com" {
+		return ""
+	}
+	id, found := strings.CutPrefix(parsed.Path, "/embed/")
+	if !found {
+		return ""
+	}
+	return strings.Trim(id, "/")
+}
+
 // parseCloudflareCodeBlock renders a <pre> element as a markdown fenced code block.
 func parseCloudflareCodeBlock(e *colly.HTMLElement) string {
 	builder := strings.Builder{}
@@ -807,3 +807,124 @@ func TestCloudflareScraper_ScrapeArticle_MixedContent(t *testing.T) {
 		}
 	}
 }
+
+func TestCloudflareScraper_ScrapeArticle_Metadata(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<meta property="article:tag" content="Workers">
+	<meta property="article:tag" content="Developer Platform">
+</head>
+<body>
+	<main id="post">
+		<article class="post-full mw-100 ph3 ph0-l fs-20px">
+			<h1 class="f6 f7-l fw4 gray1">Title</h1>
+			<p class="f3 fw5 gray5 my" data-iso-date="2024-09-26T14:00+01:00">2024-09-26</p>
+			<ul class="author-lists flex flex-wrap">
+				<li class="author-name-tooltip"><a href="/author/jane-doe/">Jane  Doe</a></li>
+				<li class="author-name-tooltip"><a href="/author/john-smith/">John Smith</a></li>
+			</ul>
+			<section class="post-full-content">
+				<div class="post-content lh-copy gray1">
+					<p>Written with <a href="/author/jane-doe/">Jane</a>, see <a href="/tag/ai/">AI</a>.</p>
+				</div>
+			</section>
+			<div class="flex flex-row flex-wrap">
+				<a href="/tag/workers/">Workers</a>
+				<a href="/tag/serverless/">Serverless</a>
+			</div>
+		</article>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &CloudflareScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\n" +
+		"date: \"2024-09-26T14:00+01:00\"\n" +
+		"authors:\n" +
+		"  - \"Jane Doe\"\n" +
+		"  - \"John Smith\"\n" +
+		"tags:\n" +
+		"  - \"Workers\"\n" +
+		"  - \"Developer Platform\"\n" +
+		"  - \"Serverless\"\n" +
+		"---\n\n" +
+		"# Title\n\n"
+	if !strings.HasPrefix(result, expected) {
+		t.Errorf("expected metadata:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestCloudflareScraper_ScrapeArticle_TablesEmbedsAndCaptions(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<main id="post">
+		<article class="post-full mw-100 ph3 ph0-l fs-20px">
+			<h1 class="f6 f7-l fw4 gray1">Title</h1>
+			<section class="post-full-content">
+				<div class="post-content lh-copy gray1">
+					<figure class="kg-card kg-image-card kg-card-hascaption">
+						<img src="/content/images/diagram.png" alt="Diagram" class="kg-image"/>
+						<figcaption><span>How requests flow through <b>Workers</b></span></figcaption>
+					</figure>
+					<div class="table-wrapper">
+						<table>
+							<thead><tr><th>Region</th><th>Latency</th></tr></thead>
+							<tbody><tr><td>EU</td><td><code>12ms</code></td></tr></tbody>
+						</table>
+					</div>
+					<figure class="kg-card kg-embed-card">
+						<iframe width="200" height="113" src="https://www.youtube.com/embed/dQw4w9WgXcQ?feature=oembed" title="Workers launch"></iframe>
+					</figure>
+					<figure class="kg-card kg-embed-card">
+						<blockquote class="twitter-tweet"><p lang="en">We launched
+						Workers AI!</p>&mdash; Cloudflare (@Cloudflare) <a href="https://twitter.com/Cloudflare/status/123">September 27, 2023</a></blockquote>
+					</figure>
+				</div>
+			</section>
+		</article>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &CloudflareScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"![Diagram](" + server.URL + "/content/images/diagram.png)\n\n" +
+		"*How requests flow through Workers*\n\n" +
+		"| Region | Latency |\n" +
+		"| --- | --- |\n" +
+		"| EU | `12ms` |\n\n" +
+		"[YouTube video: Workers launch](https://www.youtube.com/watch?v=dQw4w9WgXcQ)\n\n" +
+		"> We launched Workers AI!\n\n" +
+		"[Tweet](https://twitter.com/Cloudflare/status/123)\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
@@ -9,6 +9,8 @@ func CreateLinkScraper(sourceType string) (LinkScraper, error) {
 	case "guardian":
 		scraper := &GuardianScraper{}
 		return scraper, nil
+	case "wikipedia":
+		return &WikipediaScraper{}, nil
 
 	default:
 		return nil, fmt.Errorf("source %s is not supported", sourceType)
@@ -23,6 +25,8 @@ func CreateArticleScraper(sourceType string) (ArticleScraper, error) {
 		return &MicrosoftLearnScraper{}, nil
 	case "go":
 		return &GoDocScraper{}, nil
+	case "gopkg":
+		return &GoPackageScraper{}, nil
 	case "tofugu":
 		return &TofuguScraper{}, nil
 	case "newyorktimes":
@@ -21,6 +21,18 @@ func TestCreateLinkScraper_Guardian(t *testing.T) {
 	}
 }
 
+func TestCreateLinkScraper_Wikipedia(t *testing.T) {
+	scraper, err := CreateLinkScraper("wikipedia")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if _, ok := scraper.(*WikipediaScraper); !ok {
+		t.Errorf("expected *WikipediaScraper, got %T", scraper)
+	}
+}
+
 func TestCreateLinkScraper_UnsupportedSource(t *testing.T) {
 	scraper, err := CreateLinkScraper("unsupported")
 
@@ -101,6 +113,22 @@ func TestCreateArticleScraper_Go(t *testing.T) {
 	}
 }
 
+func TestCreateArticleScraper_GoPackage(t *testing.T) {
+	scraper, err := CreateArticleScraper("gopkg")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if scraper == nil {
+		t.Fatal("expected scraper to be non-nil")
+	}
+
+	if _, ok := scraper.(*GoPackageScraper); !ok {
+		t.Errorf("expected *GoPackageScraper, got %T", scraper)
+	}
+}
+
 func TestCreateArticleScraper_Tofugu(t *testing.T) {
 	scraper, err := CreateArticleScraper("tofugu")
 
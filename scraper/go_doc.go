@@ -3,6 +3,7 @@ package scraper
 import (
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/gocolly/colly"
 	"golang.org/x/net/html"
@@ -17,45 +18,73 @@ func (g *GoDocScraper) ScrapeArticle(url string) (string, error) {
 	c := colly.NewCollector()
 
 	var markdown string
+	metadata := frontMatter{}
 
 	// title
 	c.OnHTML("h1", func(e *colly.HTMLElement) {
+		// skip the "The Go Blog" banner of blog posts
+		if e.DOM.HasClass("small") {
+			return
+		}
 		markdown += fmt.Sprintf("# %s\n\n", e.Text)
 	})
 
-	// article
-	c.OnHTML("article", func(e *colly.HTMLElement) {
+	// byline and date of blog posts
+	c.OnHTML("p.author", func(e *colly.HTMLElement) {
+		author, date := parseGoDocByline(e)
+		metadata.set("author", author)
+		metadata.set("date", date)
+	})
+
+	// article; blog posts have their content in div.markdown instead
+	c.OnHTML("article, div.Article > div.markdown", func(e *colly.HTMLElement) {
+		anchors := collectGoDocAnchors(e)
 		e.ForEach("*", func(_ int, child *colly.HTMLElement) {
 			if child.DOM.Parent().IsSelection(e.DOM) {
 				switch child.Name {
 				case "h2":
-					markdown += fmt.Sprintf("## %s\n\n", child.Text)
+					markdown += parseGoDocHeading(child, "##", anchors)
 				case "h3":
-					markdown += fmt.Sprintf("### %s\n\n", child.Text)
+					markdown += parseGoDocHeading(child, "###", anchors)
+				case "h4":
+					markdown += parseGoDocHeading(child, "####", anchors)
 				case "p":
-					markdown += fmt.Sprintf("%s\n\n", parseGoDocParagraph(child))
+					if child.DOM.HasClass("author") {
+						return
+					}
+					markdown += fmt.Sprintf("%s\n\n", parseGoDocParagraph(child, anchors))
 				case "ul":
-					child.ForEach("li", func(_ int, li *colly.HTMLElement) {
-						markdown += fmt.Sprintf("* %s\n", parseGoDocParagraph(li))
-					})
+					markdown += parseGoDocList(child, false, "", anchors)
+					markdown += fmt.Sprintln()
+				case "ol":
+					markdown += parseGoDocList(child, true, "", anchors)
 					markdown += fmt.Sprintln()
+				case "blockquote":
+					markdown += renderMarkdownBlockquote(child, func(p *colly.HTMLElement) string {
+						return parseGoDocParagraph(p, anchors)
+					})
+				case "table":
+					markdown += renderMarkdownTable(child, func(cell *colly.HTMLElement) string {
+						return parseGoDocParagraph(cell, anchors)
+					})
+				case "img":
+					markdown += fmt.Sprintf("%s\n\n", renderMarkdownImage(child, child.DOM))
 				case "div":
 					if child.DOM.HasClass("NOTE") {
 						child.ForEach("p", func(_ int, p *colly.HTMLElement) {
 							if p.DOM.HasClass("alert") {
 								return
 							}
-							markdown += fmt.Sprintf("> %s\n\n", parseGoDocParagraph(p))
+							markdown += fmt.Sprintf("> %s\n\n", parseGoDocParagraph(p, anchors))
 						})
 						markdown += fmt.Sprintln()
+					} else if child.DOM.HasClass("image") {
+						child.ForEach("img", func(_ int, img *colly.HTMLElement) {
+							markdown += fmt.Sprintf("%s\n\n", renderMarkdownImage(img, img.DOM))
+						})
 					}
 				case "pre":
-					markdown += fmt.Sprintln("```")
-					child.ForEach("code", func(_ int, code *colly.HTMLElement) {
-						markdown += fmt.Sprintln(parseGoDocParagraph(code))
-					})
-					markdown += fmt.Sprintln("```")
-					markdown += fmt.Sprintln()
+					markdown += parseGoDocCodeBlock(child)
 				}
 			}
 		})
@@ -66,7 +95,7 @@ func (g *GoDocScraper) ScrapeArticle(url string) (string, error) {
 		return "", err
 	}
 
-	return markdown, nil
+	return metadata.String() + markdown, nil
 }
 
 func (g *GoDocScraper) ScrapeTitle(url string) (string, error) {
@@ -96,33 +125,197 @@ func (g *GoDocScraper) ScrapeFilename(url string) (string, error) {
 	return generateFileNameFromTitle(title), nil
 }
 
-func parseGoDocParagraph(p *colly.HTMLElement) string {
+// parseGoDocParagraph renders the inline content of an element as markdown,
+// preserving <code> as backtick spans, <a> as links, <img> as images and
+// rendering other inline elements in bold. Lists nested in list items are
+// rendered as indented sub-lists. Links to fragments of the page are
+// rewritten with anchors to point at the generated headings.
+func parseGoDocParagraph(p *colly.HTMLElement, anchors map[string]string) string {
 	builder := strings.Builder{}
 
-	p.ForEach("*", func(_ int, child *colly.HTMLElement) {
-		switch child.DOM.Nodes[0].Type {
+	for index, node := range p.DOM.Contents().Nodes {
+		switch node.Type {
 		case html.TextNode:
-			builder.WriteString(child.Text)
+			builder.WriteString(node.Data)
 		case html.ElementNode:
-			if child.Name == "img" {
-				// it is likely an image in GoDoc
-				builder.WriteString("_image_")
-				return
+			child := colly.NewHTMLElementFromSelectionNode(p.Response, p.DOM.FindNodes(node), node, index)
+			switch child.Name {
+			case "img":
+				builder.WriteString(renderMarkdownImage(child, child.DOM))
+				continue
+			case "br":
+				builder.WriteString("\n")
+				continue
+			case "code":
+				builder.WriteString(fmt.Sprintf("`%s`", child.Text))
+				continue
 			}
-			text := parseGoDocParagraph(child)
-			if text == "" {
-				return
+			if p.Name == "li" && (child.Name == "ul" || child.Name == "ol") {
+				// lists in list items are rendered by parseGoDocList
+				continue
+			}
+			text := parseGoDocParagraph(child, anchors)
+			if strings.TrimSpace(text) == "" {
+				continue
+			}
+			switch child.Name {
+			case "a":
+				href := child.Attr("href")
+				if href == "" {
+					builder.WriteString(text)
+					continue
+				}
+				if strings.HasPrefix(href, "#") {
+					if slug, ok := anchors[strings.TrimPrefix(href, "#")]; ok {
+						href = "#" + slug
+					}
+					builder.WriteString(fmt.Sprintf("[%s](%s)", text, href))
+					continue
+				}
+				builder.WriteString(fmt.Sprintf("[%s](%s)", text, child.Request.AbsoluteURL(href)))
+			case "i", "em":
+				builder.WriteString(fmt.Sprintf("*%s*", text))
+			case "span", "sup", "sub", "small":
+				builder.WriteString(text)
+			default:
+				builder.WriteString(fmt.Sprintf("**%s**", text))
 			}
-			if p.Name == "li" && child.Name == "ul" {
-				// list in list
-				child.ForEach("li", func(_ int, subListItem *colly.HTMLElement) {
-					builder.WriteString(fmt.Sprintf("  * %s\n", parseGoDocParagraph(subListItem)))
-				})
+		}
+	}
+
+	return builder.String()
+}
+
+// parseGoDocList renders the items of a <ul> or <ol> element, each prefixed
+// by indent. Lists in an item are rendered below it, indented by the width
+// of its marker.
+func parseGoDocList(e *colly.HTMLElement, ordered bool, indent string, anchors map[string]string) string {
+	builder := strings.Builder{}
+	index := 0
+	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
+		if !li.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		index++
+
+		marker := "* "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", index)
+		}
+		builder.WriteString(fmt.Sprintf("%s%s%s\n", indent, marker, strings.TrimSpace(parseGoDocParagraph(li, anchors))))
+
+		li.ForEach("ul, ol", func(_ int, list *colly.HTMLElement) {
+			if !list.DOM.Parent().IsSelection(li.DOM) {
 				return
 			}
-			builder.WriteString(fmt.Sprintf("**%s**", text))
+			builder.WriteString(parseGoDocList(list, list.Name == "ol", indent+strings.Repeat(" ", len(marker)), anchors))
+		})
+	})
+	return builder.String()
+}
+
+// collectGoDocAnchors maps the id of each heading rendered from the article
+// to the anchor of the generated markdown heading.
+func collectGoDocAnchors(e *colly.HTMLElement) map[string]string {
+	anchors := make(map[string]string)
+	seen := make(map[string]int)
+	e.ForEach("h2, h3, h4", func(_ int, h *colly.HTMLElement) {
+		if !h.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		slug := markdownHeadingSlug(strings.TrimSpace(h.Text), seen)
+		if id := h.Attr("id"); id != "" {
+			anchors[id] = slug
 		}
 	})
+	return anchors
+}
 
-	return builder.String()
+// parseGoDocHeading renders a heading with the given markdown prefix. When
+// the heading has an id which differs from the anchor generated from its
+// text, the id is kept as an inline HTML anchor so that existing links to
+// the section still work.
+func parseGoDocHeading(h *colly.HTMLElement, prefix string, anchors map[string]string) string {
+	text := strings.TrimSpace(h.Text)
+	id := h.Attr("id")
+	if id != "" && anchors[id] != id {
+		return fmt.Sprintf("%s <a id=\"%s\"></a>%s\n\n", prefix, id, text)
+	}
+	return fmt.Sprintf("%s %s\n\n", prefix, text)
+}
+
+// parseGoDocByline extracts the author and date from the byline of a blog
+// post. The byline consists of the author on the first line and the date
+// on the last line. Dates are converted to YYYY-MM-DD when they can be
+// parsed.
+func parseGoDocByline(e *colly.HTMLElement) (string, string) {
+	text := strings.Builder{}
+	for _, node := range e.DOM.Contents().Nodes {
+		if node.Type == html.ElementNode && node.Data == "br" {
+			text.WriteString("\n")
+			continue
+		}
+		text.WriteString(e.DOM.FindNodes(node).Text())
+	}
+
+	lines := make([]string, 0)
+	for _, line := range strings.Split(text.String(), "\n") {
+		if line = strings.TrimSpace(line); line != "" {
+			lines = append(lines, line)
+		}
+	}
+	if len(lines) == 0 {
+		return "", ""
+	}
+	if len(lines) == 1 {
+		return lines[0], ""
+	}
+	date := lines[len(lines)-1]
+	if parsed, err := time.Parse("2 January 2006", date); err == nil {
+		date = parsed.Format("2006-01-02")
+	}
+	return strings.Join(lines[:len(lines)-1], " "), date
+}
+
+// parseGoDocCodeBlock renders a <pre> element as a fenced code block tagged
+// with the language detected by detectGoDocCodeLang.
+func parseGoDocCodeBlock(e *colly.HTMLElement) string {
+	code := strings.TrimRight(e.Text, "\n")
+	lang := detectGoDocCodeLang(e, code)
+	return fmt.Sprintf("```%s\n%s\n```\n\n", lang, code)
+}
+
+// detectGoDocCodeLang guesses the language of a code block. An explicit
+// language-* class wins; the EBNF productions of the spec are tagged as
+// ebnf, snippets of shell sessions as sh, and anything that looks like Go
+// source as go. Output and other plain text is left untagged.
+func detectGoDocCodeLang(e *colly.HTMLElement, code string) string {
+	for _, part := range strings.Fields(e.Attr("class") + " " + e.ChildAttr("code", "class")) {
+		if strings.HasPrefix(part, "language-") {
+			return strings.TrimPrefix(part, "language-")
+		}
+		if part == "ebnf" || part == "grammar" {
+			return "ebnf"
+		}
+	}
+
+	trimmed := strings.TrimSpace(code)
+	if strings.HasPrefix(trimmed, "$ ") || strings.HasPrefix(trimmed, "% ") {
+		return "sh"
+	}
+
+	goPrefixes := []string{"package ", "import ", "func ", "type ", "var ", "const ", "//"}
+	for _, prefix := range goPrefixes {
+		if strings.HasPrefix(trimmed, prefix) {
+			return "go"
+		}
+	}
+	goTokens := []string{":=", "func(", "fmt.", "} else {", "for _, ", "chan ", "go func", "if err != nil"}
+	for _, token := range goTokens {
+		if strings.Contains(trimmed, token) {
+			return "go"
+		}
+	}
+
+	return ""
 }
@@ -568,9 +568,10 @@ func TestGoDocScraper_ScrapeArticle_ImageInParagraph(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	// Image should be replaced with _image_
-	if !strings.Contains(result, "_image_") {
-		t.Errorf("expected image to be replaced with _image_, got: %q", result)
+	// Image should be rendered with an absolute URL
+	expected := "![test](" + server.URL + "/image.png)"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected image %q, got: %q", expected, result)
 	}
 }
 
@@ -1323,3 +1324,372 @@ func TestGoDocScraper_ScrapeFilename_UnicodeCharacters(t *testing.T) {
 		t.Errorf("ScrapeFilename() = %q, want %q", result, expected)
 	}
 }
+
+func TestGoDocScraper_ScrapeArticle_ParagraphText(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<p>Use <code>go build</code> and read <a href="/doc/effective_go">Effective Go</a> for <em>idiomatic</em> code.</p>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "Use `go build` and read [Effective Go](" + server.URL + "/doc/effective_go) for *idiomatic* code.\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected %q in result, got: %q", expected, result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_CodeBlockLanguages(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<pre>package main
+
+func main() {}</pre>
+		<pre>$ go install golang.org/x/tools/gopls@latest</pre>
+		<pre>hello, world</pre>
+		<pre class="ebnf">Block = "{" StatementList "}" .</pre>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"```go\npackage main\n\nfunc main() {}\n```\n\n",
+		"```sh\n$ go install golang.org/x/tools/gopls@latest\n```\n\n",
+		"```\nhello, world\n```\n\n",
+		"```ebnf\nBlock = \"{\" StatementList \"}\" .\n```\n\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in result, got: %q", want, result)
+		}
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_OrderedListAndH4(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<h4>Steps</h4>
+		<ol>
+			<li>First
+				<ul><li>Detail</li></ul>
+			</li>
+			<li>Second</li>
+		</ol>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "#### Steps\n\n1. First\n   * Detail\n2. Second\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected %q in result, got: %q", expected, result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_NestedOrderedList(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<ol>
+			<li>Step one
+				<ol><li>Sub a</li><li>Sub b</li></ol>
+			</li>
+			<li>Step two</li>
+		</ol>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "1. Step one\n   1. Sub a\n   2. Sub b\n2. Step two\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected %q in result, got: %q", expected, result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_Blockquote(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<blockquote>
+			<p>Don't communicate by sharing memory.</p>
+			<p>Share memory by communicating.</p>
+		</blockquote>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "> Don't communicate by sharing memory.\n>\n> Share memory by communicating.\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected %q in result, got: %q", expected, result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_Table(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<table>
+			<tr><th>Operator</th><th>Meaning</th></tr>
+			<tr><td><code>&amp;&amp;</code></td><td>conditional AND</td></tr>
+			<tr><td><code>||</code></td><td>conditional OR</td></tr>
+		</table>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "| Operator | Meaning |\n| --- | --- |\n| `&&` | conditional AND |\n| `\\|\\|` | conditional OR |\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected %q in result, got: %q", expected, result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_ImageDiv(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<article>
+		<div class="image"><img src="/blog/gopher.png" alt="Gopher"></div>
+		<img src="https://go.dev/images/logo.svg" alt="Logo">
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"![Gopher](" + server.URL + "/blog/gopher.png)\n\n",
+		"![Logo](https://go.dev/images/logo.svg)\n\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in result, got: %q", want, result)
+		}
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_BlogPost(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<div id="blog"><div id="content">
+		<div class="Article" data-slug="/blog/go1.22">
+			<h1 class="small"><a href="/blog/">The Go Blog</a></h1>
+			<h1>Go 1.22 is released!</h1>
+			<p class="author">
+			Eli Bendersky, on behalf of the Go team<br>
+			6 February 2024
+			</p>
+			<div class="markdown">
+				<p>Today the Go team is thrilled to release Go 1.22.</p>
+			</div>
+		</div>
+	</div></div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\nauthor: \"Eli Bendersky, on behalf of the Go team\"\ndate: \"2024-02-06\"\n---\n\n" +
+		"# Go 1.22 is released!\n\n" +
+		"Today the Go team is thrilled to release Go 1.22.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_UnparsableDate(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<p class="author">Russ Cox<br>sometime in 2009</p>
+	<article></article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "author: \"Russ Cox\"\ndate: \"sometime in 2009\"\n") {
+		t.Errorf("expected raw date to be kept, got: %q", result)
+	}
+}
+
+func TestGoDocScraper_ScrapeArticle_HeadingAnchors(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Go 1.22 Release Notes</h1>
+	<article>
+		<h2 id="introduction">Introduction to Go 1.22</h2>
+		<p>See <a href="#language">language changes</a> and <a href="#nethttppkgnethttp">net/http</a>.</p>
+		<h2 id="language">Changes to the language</h2>
+		<h4 id="nethttppkgnethttp"><a href="/pkg/net/http/">net/http</a></h4>
+		<h3 id="tools">Tools</h3>
+		<p>Unknown <a href="#missing">fragment</a>.</p>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GoDocScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"## <a id=\"introduction\"></a>Introduction to Go 1.22\n\n",
+		"See [language changes](#changes-to-the-language) and [net/http](#nethttp).\n\n",
+		"## <a id=\"language\"></a>Changes to the language\n\n",
+		"#### <a id=\"nethttppkgnethttp\"></a>net/http\n\n",
+		"### Tools\n\n",
+		"Unknown [fragment](#missing).\n\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected %q in result, got: %q", want, result)
+		}
+	}
+}
@@ -0,0 +1,333 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/gocolly/colly"
+	"golang.org/x/net/html"
+)
+
+// GoPackageScraper scrapes package documentation pages of pkg.go.dev.
+type GoPackageScraper struct {
+}
+
+// ScrapeArticle scrapes the package documentation from the specified URL
+// and returns markdown in a string
+func (g *GoPackageScraper) ScrapeArticle(url string) (string, error) {
+	c := colly.NewCollector()
+
+	var markdown string
+	metadata := frontMatter{}
+
+	// import path
+	c.OnHTML("div.UnitHeader-titles [data-to-copy]", func(e *colly.HTMLElement) {
+		metadata.set("import_path", e.Attr("data-to-copy"))
+	})
+
+	// module version
+	c.OnHTML("[data-test-id=UnitHeader-version] a", func(e *colly.HTMLElement) {
+		version := strings.TrimSpace(e.Text)
+		version = strings.TrimSpace(strings.TrimPrefix(version, "Version:"))
+		metadata.set("version", version)
+	})
+
+	// license
+	c.OnHTML("[data-test-id=UnitHeader-licenses] a", func(e *colly.HTMLElement) {
+		metadata.set("license", e.Text)
+	})
+
+	// title
+	c.OnHTML("h1.UnitHeader-titleHeading", func(e *colly.HTMLElement) {
+		markdown += fmt.Sprintf("# %s\n\n", strings.TrimSpace(e.Text))
+	})
+
+	// documentation
+	c.OnHTML("div.Documentation-content", func(e *colly.HTMLElement) {
+		markdown += parseGoPackageContent(e)
+	})
+
+	err := c.Visit(url)
+	if err != nil {
+		return "", err
+	}
+
+	return metadata.String() + markdown, nil
+}
+
+func (g *GoPackageScraper) ScrapeTitle(url string) (string, error) {
+	c := colly.NewCollector()
+
+	var title string
+
+	c.OnHTML("h1.UnitHeader-titleHeading", func(e *colly.HTMLElement) {
+		title = strings.TrimSpace(e.Text)
+	})
+
+	err := c.Visit(url)
+	if err != nil {
+		return "", err
+	}
+
+	return title, nil
+}
+
+// ScrapeFilename returns the name of the package of the URL, which is the
+// last element of its import path, e.g. http for pkg.go.dev/net/http#Client.
+// The major version suffix of a module is skipped, so that
+// pkg.go.dev/github.com/jackc/pgx/v5 is named pgx.
+func (g *GoPackageScraper) ScrapeFilename(url string) (string, error) {
+	return getGoPackageName(url), nil
+}
+
+// getGoPackageName returns the last element of the import path of a
+// pkg.go.dev URL without the version, the fragment or the major version
+// suffix. It returns "package" if the URL has no import path.
+func getGoPackageName(pageURL string) string {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "package"
+	}
+	var elements []string
+	for _, element := range strings.Split(parsed.Path, "/") {
+		// versions are in the form of path@version
+		element, _, _ = strings.Cut(element, "@")
+		if element != "" {
+			elements = append(elements, element)
+		}
+	}
+	if len(elements) > 1 && isGoMajorVersionSuffix(elements[len(elements)-1]) {
+		elements = elements[:len(elements)-1]
+	}
+	if len(elements) == 0 {
+		return "package"
+	}
+	return elements[len(elements)-1]
+}
+
+// isGoMajorVersionSuffix returns true if an element of an import path is the
+// major version suffix of a module, e.g. v2.
+func isGoMajorVersionSuffix(element string) bool {
+	number, found := strings.CutPrefix(element, "v")
+	if !found || number == "" {
+		return false
+	}
+	for _, r := range number {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
+// parseGoPackageContent renders the direct children of a documentation
+// container. Containers without a markdown equivalent (sections and plain
+// divs) are walked recursively.
+func parseGoPackageContent(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+
+	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
+		if !child.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+
+		switch child.Name {
+		case "h3":
+			builder.WriteString(fmt.Sprintf("## %s\n\n", parseGoPackageHeading(child)))
+		case "h4":
+			builder.WriteString(parseGoPackageHeader(child))
+		case "p":
+			text := strings.TrimSpace(parseGoPackageInline(child))
+			if text != "" {
+				builder.WriteString(fmt.Sprintf("%s\n\n", text))
+			}
+		case "pre":
+			code := strings.TrimRight(child.Text, "\n")
+			builder.WriteString(fmt.Sprintf("```\n%s\n```\n\n", code))
+		case "ul":
+			if child.DOM.HasClass("Documentation-indexList") {
+				builder.WriteString(parseGoPackageIndex(child, 0))
+				builder.WriteString("\n")
+				return
+			}
+			builder.WriteString(parseGoPackageList(child, false))
+		case "ol":
+			builder.WriteString(parseGoPackageList(child, true))
+		case "details":
+			if child.DOM.HasClass("Documentation-exampleDetails") {
+				builder.WriteString(parseGoPackageExample(child))
+			}
+		case "div":
+			if child.DOM.HasClass("Documentation-declaration") {
+				builder.WriteString(parseGoPackageDeclaration(child))
+				return
+			}
+			builder.WriteString(parseGoPackageContent(child))
+		case "section":
+			// the list of examples duplicates the examples rendered inline
+			if child.DOM.HasClass("Documentation-examples") {
+				return
+			}
+			builder.WriteString(parseGoPackageContent(child))
+		}
+	})
+
+	return builder.String()
+}
+
+// parseGoPackageHeader renders a <h4> element. Section headers (Constants,
+// Variables, Functions and Types) become level 2 headings, declarations of
+// functions and types become level 3 headings, and functions and methods
+// belonging to a type become level 4 headings. Headings inside package
+// comments are rendered as level 3 headings.
+func parseGoPackageHeader(e *colly.HTMLElement) string {
+	text := parseGoPackageHeading(e)
+	if text == "" {
+		return ""
+	}
+	switch {
+	case e.DOM.HasClass("Documentation-constantsHeader"),
+		e.DOM.HasClass("Documentation-variablesHeader"),
+		e.DOM.HasClass("Documentation-functionsHeader"),
+		e.DOM.HasClass("Documentation-typesHeader"):
+		return fmt.Sprintf("## %s\n\n", text)
+	case e.DOM.HasClass("Documentation-typeFuncHeader"),
+		e.DOM.HasClass("Documentation-typeMethodHeader"):
+		return fmt.Sprintf("#### %s\n\n", text)
+	default:
+		return fmt.Sprintf("### %s\n\n", text)
+	}
+}
+
+// parseGoPackageHeading extracts the text of a heading, excluding the "¶"
+// anchor links and the "added in" version annotation.
+func parseGoPackageHeading(e *colly.HTMLElement) string {
+	clone := e.DOM.Clone()
+	clone.Find("a.Documentation-idLink, span.Documentation-sinceVersion").Remove()
+	text := strings.TrimSpace(clone.Text())
+	text = strings.TrimSpace(strings.TrimSuffix(text, "¶"))
+	return strings.Join(strings.Fields(text), " ")
+}
+
+// parseGoPackageDeclaration renders the signature of a declaration as a
+// fenced Go code block.
+func parseGoPackageDeclaration(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+	e.ForEach("pre", func(_ int, pre *colly.HTMLElement) {
+		code := strings.TrimRight(pre.Text, "\n")
+		builder.WriteString(fmt.Sprintf("```go\n%s\n```\n\n", code))
+	})
+	return builder.String()
+}
+
+// parseGoPackageExample renders a collapsible example as a heading followed
+// by its code and, when present, its expected output.
+func parseGoPackageExample(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+
+	title := strings.TrimSpace(e.ChildText("summary"))
+	title = strings.TrimSpace(strings.TrimSuffix(title, "¶"))
+	if title == "" {
+		title = "Example"
+	}
+	builder.WriteString(fmt.Sprintf("**%s**\n\n", title))
+
+	e.ForEach("p", func(_ int, p *colly.HTMLElement) {
+		text := strings.TrimSpace(parseGoPackageInline(p))
+		if text != "" {
+			builder.WriteString(fmt.Sprintf("%s\n\n", text))
+		}
+	})
+
+	// runnable examples are rendered in a <textarea> for the playground
+	e.ForEach("pre, textarea", func(_ int, pre *colly.HTMLElement) {
+		if pre.DOM.HasClass("Documentation-exampleCode") {
+			code := strings.TrimRight(pre.Text, "\n")
+			builder.WriteString(fmt.Sprintf("```go\n%s\n```\n\n", code))
+			return
+		}
+		output := strings.TrimRight(pre.ChildText("span.Documentation-exampleOutput"), "\n")
+		if output == "" {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("Output:\n\n```\n%s\n```\n\n", output))
+	})
+
+	return builder.String()
+}
+
+// parseGoPackageIndex renders the index of a package as a nested list of
+// declarations.
+func parseGoPackageIndex(e *colly.HTMLElement, level int) string {
+	builder := strings.Builder{}
+	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
+		if !li.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		text := strings.Join(strings.Fields(li.DOM.ChildrenFiltered("a").First().Text()), " ")
+		if text == "" {
+			return
+		}
+		builder.WriteString(fmt.Sprintf("%s* %s\n", strings.Repeat("  ", level), text))
+		li.ForEach("ul", func(_ int, sub *colly.HTMLElement) {
+			if !sub.DOM.Parent().IsSelection(li.DOM) {
+				return
+			}
+			builder.WriteString(parseGoPackageIndex(sub, level+1))
+		})
+	})
+	return builder.String()
+}
+
+// parseGoPackageList renders a <ul> or <ol> element of a doc comment as
+// markdown.
+func parseGoPackageList(e *colly.HTMLElement, ordered bool) string {
+	builder := strings.Builder{}
+	index := 0
+	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
+		if !li.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		index++
+		text := strings.TrimSpace(parseGoPackageInline(li))
+		if ordered {
+			builder.WriteString(fmt.Sprintf("%d. %s\n", index, text))
+		} else {
+			builder.WriteString(fmt.Sprintf("* %s\n", text))
+		}
+	})
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// parseGoPackageInline renders the inline content of a doc comment as
+// markdown, preserving <code> as backtick spans and <a> as markdown links.
+// Links to other packages are made absolute, links within the page are
+// emitted as plain text.
+func parseGoPackageInline(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+	for _, node := range e.DOM.Contents().Nodes {
+		switch node.Type {
+		case html.TextNode:
+			builder.WriteString(node.Data)
+		case html.ElementNode:
+			sel := e.DOM.FindNodes(node)
+			switch node.Data {
+			case "code":
+				builder.WriteString(fmt.Sprintf("`%s`", sel.Text()))
+			case "a":
+				href, _ := sel.Attr("href")
+				linkText := sel.Text()
+				if href == "" || strings.HasPrefix(href, "#") {
+					builder.WriteString(linkText)
+					continue
+				}
+				builder.WriteString(fmt.Sprintf("[%s](%s)", linkText, e.Request.AbsoluteURL(href)))
+			default:
+				builder.WriteString(sel.Text())
+			}
+		}
+	}
+	return builder.String()
+}
@@ -0,0 +1,325 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+const goPackageTestPage = `<!DOCTYPE html>
+<html>
+<head><title>http package - net/http - Go Packages</title></head>
+<body>
+	<header class="UnitHeader">
+		<div class="UnitHeader-titles">
+			<h1 class="UnitHeader-titleHeading" data-test-id="UnitHeader-title">http</h1>
+			<button class="CopyPathButton" data-to-copy="net/http" aria-label="Copy path to clipboard"></button>
+		</div>
+		<div class="UnitHeader-details">
+			<span class="UnitHeader-detailItem" data-test-id="UnitHeader-version">
+				<a href="?tab=versions" aria-label="Version: go1.22.0">
+					<span class="UnitHeader-detailItemSubtle">Version: </span>go1.22.0
+				</a>
+			</span>
+			<span class="UnitHeader-detailItem" data-test-id="UnitHeader-licenses">
+				<a href="/net/http?tab=licenses" data-test-id="UnitHeader-license">BSD-3-Clause</a>
+			</span>
+		</div>
+	</header>
+	<div class="Documentation-content js-docContent">
+		<section class="Documentation-overview">
+			<h3 tabindex="-1" id="pkg-overview" class="Documentation-overviewHeader">Overview <a href="#pkg-overview">¶</a></h3>
+			<p>Package http provides <a href="/net/url">URL</a> helpers for <code>Get</code>.</p>
+			<h4 id="hdr-Clients">Clients</h4>
+			<pre>resp, err := http.Get("http://example.com/")</pre>
+		</section>
+		<section class="Documentation-index">
+			<h3 id="pkg-index" class="Documentation-indexHeader">Index <a href="#pkg-index">¶</a></h3>
+			<ul class="Documentation-indexList">
+				<li class="Documentation-indexConstants"><a href="#pkg-constants">Constants</a></li>
+				<li><a href="#CanonicalHeaderKey">func CanonicalHeaderKey(s string) string</a></li>
+				<li><a href="#Client">type Client</a>
+					<ul>
+						<li><a href="#Client.Do">func (c *Client) Do(req *Request) (*Response, error)</a></li>
+					</ul>
+				</li>
+			</ul>
+		</section>
+		<section class="Documentation-examples">
+			<h4 id="pkg-examples">Examples</h4>
+			<ul class="Documentation-examplesList"><li><a href="#example-Get">Get</a></li></ul>
+		</section>
+		<h4 tabindex="-1" id="pkg-constants" class="Documentation-constantsHeader">Constants <a href="#pkg-constants">¶</a></h4>
+		<section class="Documentation-constants">
+			<div class="Documentation-declaration"><pre>const DefaultMaxHeaderBytes = 1 &lt;&lt; 20</pre></div>
+			<p>DefaultMaxHeaderBytes is the maximum permitted size.</p>
+		</section>
+		<h4 tabindex="-1" id="pkg-functions" class="Documentation-functionsHeader">Functions <a href="#pkg-functions">¶</a></h4>
+		<section class="Documentation-functions">
+			<div class="Documentation-function">
+				<h4 tabindex="-1" id="CanonicalHeaderKey" data-kind="function" class="Documentation-functionHeader">
+					<span>func <a class="Documentation-source" href="https://cs.opensource.google/go">CanonicalHeaderKey</a> <a class="Documentation-idLink" href="#CanonicalHeaderKey">¶</a></span>
+					<span class="Documentation-sinceVersion"></span>
+				</h4>
+				<div class="Documentation-declaration"><pre>func CanonicalHeaderKey(s <a href="/builtin#string">string</a>) <a href="/builtin#string">string</a></pre></div>
+				<p>CanonicalHeaderKey returns the canonical format of the header key s.</p>
+				<details tabindex="-1" id="example-Get" class="Documentation-exampleDetails js-exampleContainer">
+					<summary class="Documentation-exampleDetailsHeader">Example (Get) <a href="#example-Get">¶</a></summary>
+					<div class="Documentation-exampleDetailsBody">
+						<textarea class="Documentation-exampleCode code" spellcheck="false">fmt.Println(http.CanonicalHeaderKey("accept-encoding"))</textarea>
+						<pre><span class="Documentation-exampleOutputLabel">Output:</span>
+<span class="Documentation-exampleOutput">Accept-Encoding
+</span></pre>
+					</div>
+				</details>
+			</div>
+		</section>
+		<h4 tabindex="-1" id="pkg-types" class="Documentation-typesHeader">Types <a href="#pkg-types">¶</a></h4>
+		<section class="Documentation-types">
+			<div class="Documentation-type">
+				<h4 tabindex="-1" id="Client" data-kind="type" class="Documentation-typeHeader">
+					<span>type <a class="Documentation-source" href="https://cs.opensource.google/go">Client</a> <a class="Documentation-idLink" href="#Client">¶</a></span>
+				</h4>
+				<div class="Documentation-declaration"><pre>type Client struct {
+	Timeout time.Duration
+}</pre></div>
+				<p>A Client is an HTTP client.</p>
+				<div class="Documentation-typeMethod">
+					<h4 tabindex="-1" id="Client.Do" data-kind="method" class="Documentation-typeMethodHeader">
+						<span>func (*Client) <a class="Documentation-source" href="https://cs.opensource.google/go">Do</a> <a class="Documentation-idLink" href="#Client.Do">¶</a></span>
+					</h4>
+					<div class="Documentation-declaration"><pre>func (c *Client) Do(req *Request) (*Response, error)</pre></div>
+					<p>Do sends an HTTP request. See <a href="#Client">Client</a>.</p>
+				</div>
+			</div>
+		</section>
+	</div>
+</body>
+</html>`
+
+func newGoPackageTestServer(body string) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(body))
+	}))
+}
+
+func TestGoPackageScraper_ScrapeArticle_Metadata(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\nimport_path: \"net/http\"\nversion: \"go1.22.0\"\nlicense: \"BSD-3-Clause\"\n---\n\n# http\n\n"
+	if !strings.HasPrefix(result, expected) {
+		t.Errorf("expected front matter and title at the top, got: %q", result)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_Overview(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"## Overview\n\n",
+		"Package http provides [URL](" + server.URL + "/net/url) helpers for `Get`.\n\n",
+		"### Clients\n\n",
+		"```\nresp, err := http.Get(\"http://example.com/\")\n```\n\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected result to contain %q, got: %q", want, result)
+		}
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_Index(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "## Index\n\n* Constants\n* func CanonicalHeaderKey(s string) string\n* type Client\n  * func (c *Client) Do(req *Request) (*Response, error)\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected nested index, got: %q", result)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_SkipsExamplesList(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(result, "### Examples") {
+		t.Errorf("expected list of examples to be skipped, got: %q", result)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_Declarations(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"## Constants\n\n```go\nconst DefaultMaxHeaderBytes = 1 << 20\n```\n\nDefaultMaxHeaderBytes is the maximum permitted size.\n\n",
+		"## Functions\n\n### func CanonicalHeaderKey\n\n```go\nfunc CanonicalHeaderKey(s string) string\n```\n\n",
+		"## Types\n\n### type Client\n\n```go\ntype Client struct {\n\tTimeout time.Duration\n}\n```\n\nA Client is an HTTP client.\n\n",
+		"#### func (*Client) Do\n\n```go\nfunc (c *Client) Do(req *Request) (*Response, error)\n```\n\n",
+	}
+	for _, want := range expected {
+		if !strings.Contains(result, want) {
+			t.Errorf("expected result to contain %q, got: %q", want, result)
+		}
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_InPageLinkAsText(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "Do sends an HTTP request. See Client.\n\n") {
+		t.Errorf("expected in-page link rendered as text, got: %q", result)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_Example(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "**Example (Get)**\n\n```go\nfmt.Println(http.CanonicalHeaderKey(\"accept-encoding\"))\n```\n\nOutput:\n\n```\nAccept-Encoding\n```\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected example with output, got: %q", result)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_NoMetadata(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<body>
+	<h1 class="UnitHeader-titleHeading">example</h1>
+	<div class="Documentation-content"></div>
+</body>
+</html>`
+	server := newGoPackageTestServer(html)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# example\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestGoPackageScraper_ScrapeArticle_InvalidURL(t *testing.T) {
+	scraper := &GoPackageScraper{}
+	_, err := scraper.ScrapeArticle("http://invalid-url-that-does-not-exist.local")
+
+	if err == nil {
+		t.Error("expected error for invalid URL, got nil")
+	}
+}
+
+func TestGoPackageScraper_ScrapeTitle_Basic(t *testing.T) {
+	server := newGoPackageTestServer(goPackageTestPage)
+	defer server.Close()
+
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeTitle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "http" {
+		t.Errorf("ScrapeTitle() = %q, want %q", result, "http")
+	}
+}
+
+func TestGoPackageScraper_ScrapeFilename_UsesURLBasename(t *testing.T) {
+	scraper := &GoPackageScraper{}
+	result, err := scraper.ScrapeFilename("https://pkg.go.dev/net/http")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "http" {
+		t.Errorf("ScrapeFilename() = %q, want %q", result, "http")
+	}
+}
+
+func TestGoPackageScraper_ScrapeFilename_ImportPath(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://pkg.go.dev/net/http#Client", "http"},
+		{"https://pkg.go.dev/github.com/jackc/pgx/v5", "pgx"},
+		{"https://pkg.go.dev/github.com/jackc/pgx/v5@v5.5.0/pgxpool", "pgxpool"},
+		{"https://pkg.go.dev/github.com/spf13/cobra@v1.9.1", "cobra"},
+		{"https://pkg.go.dev/gopkg.in/yaml.v3?tab=doc", "yaml.v3"},
+	}
+
+	scraper := &GoPackageScraper{}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			result, err := scraper.ScrapeFilename(tt.url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ScrapeFilename(%q) = %q, want %q", tt.url, result, tt.expected)
+			}
+		})
+	}
+}
@@ -5,19 +5,52 @@ import (
 	"net/url"
 	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly"
 	"golang.org/x/net/html"
 )
 
+// grafanaAdmonitions maps the types of admonitions to their labels.
+var grafanaAdmonitions = map[string]string{
+	"note":    "Note",
+	"tip":     "Tip",
+	"caution": "Caution",
+	"warning": "Warning",
+}
+
 type GrafanaScraper struct {
+	// Version is the version of the documentation to scrape instead of the
+	// version in the URL, e.g. v10.4, latest or next.
+	Version string
+}
+
+// grafanaOptions holds the state of rendering an article.
+type grafanaOptions struct {
+	// level is the level of the last heading, under which tabs are rendered
+	// as subsections
+	level int
 }
 
 // ScrapeArticle scrapes the article content from the specified URL
 // and returns markdown in a string
 func (g *GrafanaScraper) ScrapeArticle(url string) (string, error) {
+	url, err := g.versionURL(url)
+	if err != nil {
+		return "", err
+	}
+
 	c := colly.NewCollector()
 
 	var markdown string
+	var selectedVersion string
+
+	// version selector of documentation
+	c.OnHTML("select[name*='version'] option[selected], select[id*='version'] option[selected]", func(e *colly.HTMLElement) {
+		selectedVersion = strings.TrimSpace(e.Attr("value"))
+		if selectedVersion == "" {
+			selectedVersion = strings.TrimSpace(e.Text)
+		}
+	})
 
 	// title
 	c.OnHTML("main h1", func(e *colly.HTMLElement) {
@@ -28,18 +61,31 @@ func (g *GrafanaScraper) ScrapeArticle(url string) (string, error) {
 
 	// article body
 	c.OnHTML("div.rich-text", func(e *colly.HTMLElement) {
-		markdown += parseGrafanaContent(e)
+		markdown += parseGrafanaContent(e, &grafanaOptions{level: 1})
 	})
 
-	err := c.Visit(url)
+	err = c.Visit(url)
 	if err != nil {
 		return "", err
 	}
 
-	return markdown, nil
+	metadata := frontMatter{}
+	// the version selected on the page names the release of latest and next
+	version := selectedVersion
+	if version == "" {
+		version = getGrafanaDocsVersion(url)
+	}
+	metadata.set("version", version)
+
+	return metadata.String() + markdown, nil
 }
 
 func (g *GrafanaScraper) ScrapeTitle(url string) (string, error) {
+	url, err := g.versionURL(url)
+	if err != nil {
+		return "", err
+	}
+
 	c := colly.NewCollector()
 
 	var title string
@@ -50,7 +96,7 @@ func (g *GrafanaScraper) ScrapeTitle(url string) (string, error) {
 		}
 	})
 
-	err := c.Visit(url)
+	err = c.Visit(url)
 	if err != nil {
 		return "", err
 	}
@@ -62,7 +108,73 @@ func (g *GrafanaScraper) ScrapeFilename(url string) (string, error) {
 	return getBasenameFromURL(url), nil
 }
 
-func parseGrafanaContent(e *colly.HTMLElement) string {
+// versionURL returns the URL of the page in the version of documentation
+// specified by Version, which is the URL itself if Version is empty.
+func (g *GrafanaScraper) versionURL(pageURL string) (string, error) {
+	if g.Version == "" {
+		return pageURL, nil
+	}
+	return setGrafanaDocsVersion(pageURL, g.Version)
+}
+
+// getGrafanaDocsVersion returns the version of documentation in a URL such as
+// https://grafana.com/docs/grafana/v10.4/alerting/, which is v10.4. It
+// returns an empty string if the URL is not of versioned documentation.
+func getGrafanaDocsVersion(pageURL string) string {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return ""
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "docs" || !isGrafanaDocsVersion(segments[2]) {
+		return ""
+	}
+	return segments[2]
+}
+
+// setGrafanaDocsVersion returns the URL of the same page of documentation in
+// another version, e.g. v10.4 or 10.4.
+func setGrafanaDocsVersion(pageURL string, version string) (string, error) {
+	if !strings.HasPrefix(version, "v") && version != "latest" && version != "next" {
+		version = "v" + version
+	}
+	if !isGrafanaDocsVersion(version) {
+		return "", fmt.Errorf("invalid version of documentation: %s", version)
+	}
+
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", err
+	}
+	segments := strings.Split(strings.Trim(parsed.Path, "/"), "/")
+	if len(segments) < 3 || segments[0] != "docs" || !isGrafanaDocsVersion(segments[2]) {
+		return "", fmt.Errorf("unable to find the version of documentation in %s", pageURL)
+	}
+	segments[2] = version
+	parsed.Path = "/" + strings.Join(segments, "/") + "/"
+	parsed.RawPath = ""
+	return parsed.String(), nil
+}
+
+// isGrafanaDocsVersion returns true if a segment of a URL path is a version
+// of documentation, e.g. latest, next or v10.4.
+func isGrafanaDocsVersion(segment string) bool {
+	if segment == "latest" || segment == "next" {
+		return true
+	}
+	number, found := strings.CutPrefix(segment, "v")
+	if !found || number == "" {
+		return false
+	}
+	for _, r := range number {
+		if (r < '0' || r > '9') && r != '.' && r != 'x' {
+			return false
+		}
+	}
+	return true
+}
+
+func parseGrafanaContent(e *colly.HTMLElement, opts *grafanaOptions) string {
 	builder := strings.Builder{}
 
 	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
@@ -73,10 +185,13 @@ func parseGrafanaContent(e *colly.HTMLElement) string {
 		switch child.Name {
 		case "h2":
 			builder.WriteString(fmt.Sprintf("## %s\n\n", parseGrafanaHeading(child)))
+			opts.level = 2
 		case "h3":
 			builder.WriteString(fmt.Sprintf("### %s\n\n", parseGrafanaHeading(child)))
+			opts.level = 3
 		case "h4":
 			builder.WriteString(fmt.Sprintf("#### %s\n\n", parseGrafanaHeading(child)))
+			opts.level = 4
 		case "p":
 			text := strings.TrimSpace(parseGrafanaInline(child))
 			if text != "" {
@@ -87,9 +202,20 @@ func parseGrafanaContent(e *colly.HTMLElement) string {
 		case "ol":
 			builder.WriteString(parseGrafanaList(child, true))
 		case "div":
-			if child.DOM.HasClass("relative") {
+			if label := grafanaAdmonitionLabel(child.DOM); label != "" {
+				builder.WriteString(parseGrafanaAdmonition(child, label, opts))
+			} else if child.DOM.ChildrenFiltered("[role='tablist']").Length() > 0 {
+				builder.WriteString(parseGrafanaTabs(child, opts))
+			} else if child.DOM.HasClass("relative") {
 				builder.WriteString(parseGrafanaCodeBlock(child))
+			} else {
+				// tables of the docs are inside overflow containers
+				child.ForEach("table", func(_ int, table *colly.HTMLElement) {
+					builder.WriteString(renderMarkdownTable(table, parseGrafanaInline))
+				})
 			}
+		case "table":
+			builder.WriteString(renderMarkdownTable(child, parseGrafanaInline))
 		case "pre":
 			builder.WriteString(parseGrafanaCodeBlock(child))
 		case "img":
@@ -100,6 +226,43 @@ func parseGrafanaContent(e *colly.HTMLElement) string {
 	return builder.String()
 }
 
+// grafanaAdmonitionLabel returns the label of an admonition box such as
+// <div class="admonition admonition-note">. It returns an empty string if
+// the element is not an admonition.
+func grafanaAdmonitionLabel(sel *goquery.Selection) string {
+	if !sel.HasClass("admonition") {
+		return ""
+	}
+	for _, class := range strings.Fields(sel.AttrOr("class", "")) {
+		if label, ok := grafanaAdmonitions[strings.TrimPrefix(class, "admonition-")]; ok {
+			return label
+		}
+	}
+	return "Note"
+}
+
+// parseGrafanaAdmonition renders an admonition box as a blockquote starting
+// with its label in bold. The title of the box, which repeats the type, is
+// replaced by the label.
+func parseGrafanaAdmonition(e *colly.HTMLElement, label string, opts *grafanaOptions) string {
+	content := e
+	if quote := e.DOM.ChildrenFiltered("blockquote"); quote.Length() > 0 {
+		content = colly.NewHTMLElementFromSelectionNode(e.Response, quote.First(), quote.Get(0), 0)
+	}
+	content.DOM.ChildrenFiltered(".title").Remove()
+
+	return quoteMarkdownCallout(label, parseGrafanaContent(content, opts))
+}
+
+// parseGrafanaTabs renders a group of tabs, such as code examples in several
+// languages, with each panel as a subsection titled by the label of its tab.
+func parseGrafanaTabs(e *colly.HTMLElement, opts *grafanaOptions) string {
+	level := min(opts.level+1, 6)
+	return renderMarkdownTabs(parseAriaTabs(e), level, func(panel *colly.HTMLElement) string {
+		return parseGrafanaContent(panel, &grafanaOptions{level: level})
+	})
+}
+
 // parseGrafanaHeading extracts the heading text.
 // Grafana headings contain an anchor <a> link followed by a <span> with the visible text.
 func parseGrafanaHeading(e *colly.HTMLElement) string {
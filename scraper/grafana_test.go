@@ -654,3 +654,255 @@ func TestGrafanaScraper_ScrapeArticle_MixedContent(t *testing.T) {
 		}
 	}
 }
+
+func TestGrafanaScraper_ScrapeArticle_Admonition(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<main>
+		<h1>Title</h1>
+		<div class="rich-text">
+			<div class="admonition admonition-caution">
+				<blockquote>
+					<p class="title text-uppercase">caution</p>
+					<p>Back up the <code>grafana.db</code> file first.</p>
+					<p>Downgrades are not supported.</p>
+				</blockquote>
+			</div>
+		</div>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "> **Caution**\n>\n> Back up the `grafana.db` file first.\n>\n> Downgrades are not supported.\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected admonition %q, got: %q", expected, result)
+	}
+	if strings.Contains(result, "> caution") {
+		t.Errorf("expected the title of the admonition to be replaced by the label, got: %q", result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_Tabs(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<main>
+		<h1>Title</h1>
+		<div class="rich-text">
+			<h2><span>Install</span></h2>
+			<div class="tabs">
+				<div role="tablist">
+					<button role="tab" id="tab-debian" aria-selected="true">Debian</button>
+					<button role="tab" id="tab-macos">macOS</button>
+				</div>
+				<div role="tabpanel" aria-labelledby="tab-debian">
+					<p>Use apt.</p>
+				</div>
+				<div role="tabpanel" aria-labelledby="tab-macos" hidden>
+					<p>Use Homebrew.</p>
+				</div>
+			</div>
+		</div>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "## Install\n\n### Debian\n\nUse apt.\n\n### macOS\n\nUse Homebrew.\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected tabs as subsections %q, got: %q", expected, result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_Table(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<main>
+		<h1>Title</h1>
+		<div class="rich-text">
+			<div class="overflow-x-auto">
+				<table>
+					<thead><tr><th>Option</th><th>Description</th></tr></thead>
+					<tbody>
+						<tr><td><code>http_port</code></td><td>Port to bind, default
+							<strong>3000</strong></td></tr>
+						<tr><td><code>protocol</code></td><td>http | https</td></tr>
+					</tbody>
+				</table>
+			</div>
+		</div>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "| Option | Description |\n" +
+		"| --- | --- |\n" +
+		"| `http_port` | Port to bind, default **3000** |\n" +
+		"| `protocol` | http \\| https |\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected table %q, got: %q", expected, result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_VersionFromURL(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<main>
+		<h1>Alerting</h1>
+		<div class="rich-text"><p>Content</p></div>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL + "/docs/grafana/v10.4/alerting/")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(result, "---\nversion: \"v10.4\"\n---\n\n") {
+		t.Errorf("expected version in metadata, got: %q", result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_VersionFromSelector(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<select name="version">
+		<option value="v11.3" selected>v11.3 (latest)</option>
+		<option value="v10.4">v10.4</option>
+	</select>
+	<main>
+		<h1>Alerting</h1>
+		<div class="rich-text"><p>Content</p></div>
+	</main>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL + "/docs/grafana/latest/alerting/")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "version: \"v11.3\"\n") {
+		t.Errorf("expected version selected on the page in metadata, got: %q", result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_Version(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/docs/grafana/v10.4/alerting/" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(`<html><body><main><h1>Alerting</h1><div class="rich-text"><p>Old content</p></div></main></body></html>`))
+	}))
+	defer server.Close()
+
+	scraper := &GrafanaScraper{Version: "10.4"}
+	result, err := scraper.ScrapeArticle(server.URL + "/docs/grafana/latest/alerting/")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "Old content") {
+		t.Errorf("expected content of the specified version, got: %q", result)
+	}
+	if !strings.Contains(result, "version: \"v10.4\"\n") {
+		t.Errorf("expected specified version in metadata, got: %q", result)
+	}
+}
+
+func TestGrafanaScraper_ScrapeArticle_VersionNotInURL(t *testing.T) {
+	scraper := &GrafanaScraper{Version: "v10.4"}
+	_, err := scraper.ScrapeArticle("https://grafana.com/docs/grafana-cloud/alerting/")
+
+	if err == nil {
+		t.Fatal("expected error for URL without version, got nil")
+	}
+}
+
+func TestGetGrafanaDocsVersion(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://grafana.com/docs/grafana/v10.4/alerting/", "v10.4"},
+		{"https://grafana.com/docs/grafana/latest/alerting/", "latest"},
+		{"https://grafana.com/docs/loki/v3.0.x/get-started/", "v3.0.x"},
+		{"https://grafana.com/docs/grafana-cloud/alerting/", ""},
+		{"https://grafana.com/blog/2024/01/01/post/", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			if got := getGrafanaDocsVersion(tt.url); got != tt.expected {
+				t.Errorf("getGrafanaDocsVersion(%q) = %q, want %q", tt.url, got, tt.expected)
+			}
+		})
+	}
+}
@@ -2,12 +2,17 @@ package scraper
 
 import (
 	"fmt"
+	"slices"
 	"strings"
+	"time"
 
 	"github.com/gocolly/colly"
 )
 
 type GuardianScraper struct {
+	// OldestFirst renders the entries of live blogs in chronological order
+	// instead of the newest-first order of the page.
+	OldestFirst bool
 }
 
 // ScrapeLinks scrapes links from the specified URL
@@ -38,6 +43,24 @@ func (g *GuardianScraper) ScrapeArticle(url string) (string, error) {
 	c := colly.NewCollector()
 
 	var markdown string
+	metadata := frontMatter{}
+	authors := make([]string, 0)
+
+	// authors
+	c.OnHTML("a[rel=author]", func(e *colly.HTMLElement) {
+		author := strings.TrimSpace(e.Text)
+		if author != "" && !slices.Contains(authors, author) {
+			authors = append(authors, author)
+		}
+	})
+
+	// publication dates
+	c.OnHTML("meta[property='article:published_time']", func(e *colly.HTMLElement) {
+		metadata.set("date", e.Attr("content"))
+	})
+	c.OnHTML("meta[property='article:modified_time']", func(e *colly.HTMLElement) {
+		metadata.set("updated", e.Attr("content"))
+	})
 
 	// title
 	c.OnHTML("h1", func(e *colly.HTMLElement) {
@@ -49,9 +72,28 @@ func (g *GuardianScraper) ScrapeArticle(url string) (string, error) {
 		markdown += fmt.Sprintf("## %s\n\n", e.Text)
 	})
 
+	// main image
+	c.OnHTML("div[data-gu-name=media] figure", func(e *colly.HTMLElement) {
+		markdown += renderMarkdownFigure(e, nil)
+	})
+
+	// key events of live blogs
+	c.OnHTML("#key-events-carousel", func(e *colly.HTMLElement) {
+		markdown += parseGuardianKeyEvents(e, g.OldestFirst)
+	})
+
+	// entries of live blogs
+	c.OnHTML("div#liveblog-body", func(e *colly.HTMLElement) {
+		markdown += parseGuardianLiveBlog(e, g.OldestFirst)
+	})
+
 	// article body
-	c.OnHTML("div.article-body-commercial-selector p", func(e *colly.HTMLElement) {
-		markdown += fmt.Sprintf("%s\n\n", e.Text)
+	c.OnHTML("div.article-body-commercial-selector", func(e *colly.HTMLElement) {
+		// live blog entries have been rendered above
+		if e.DOM.Closest("div#liveblog-body").Length() > 0 {
+			return
+		}
+		markdown += parseGuardianContent(e)
 	})
 
 	err := c.Visit(url)
@@ -59,7 +101,9 @@ func (g *GuardianScraper) ScrapeArticle(url string) (string, error) {
 		return "", err
 	}
 
-	return markdown, nil
+	metadata.setList("authors", authors)
+
+	return metadata.String() + markdown, nil
 }
 
 func (g *GuardianScraper) ScrapeTitle(url string) (string, error) {
@@ -88,3 +132,114 @@ func (g *GuardianScraper) ScrapeFilename(url string) (string, error) {
 
 	return generateFileNameFromTitle(title), nil
 }
+
+func parseGuardianContent(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+
+	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
+		if !child.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+
+		switch child.Name {
+		case "h2":
+			builder.WriteString(fmt.Sprintf("## %s\n\n", strings.TrimSpace(child.Text)))
+		case "h3":
+			builder.WriteString(fmt.Sprintf("### %s\n\n", strings.TrimSpace(child.Text)))
+		case "h4":
+			builder.WriteString(fmt.Sprintf("#### %s\n\n", strings.TrimSpace(child.Text)))
+		case "p":
+			text := strings.TrimSpace(renderMarkdownInline(child))
+			if text != "" {
+				builder.WriteString(fmt.Sprintf("%s\n\n", text))
+			}
+		case "ul":
+			builder.WriteString(renderMarkdownList(child, false))
+		case "ol":
+			builder.WriteString(renderMarkdownList(child, true))
+		case "blockquote":
+			builder.WriteString(renderMarkdownBlockquote(child, renderMarkdownInline))
+		case "figure":
+			builder.WriteString(renderMarkdownFigure(child, nil))
+		case "div":
+			// paragraphs are occasionally wrapped in containers for adverts
+			builder.WriteString(parseGuardianContent(child))
+		}
+	})
+
+	return builder.String()
+}
+
+// parseGuardianKeyEvents renders the key events summary of a live blog as a
+// list of timestamped entries.
+func parseGuardianKeyEvents(e *colly.HTMLElement, oldestFirst bool) string {
+	events := make([]string, 0)
+	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
+		timestamp := parseGuardianTimestamp(li)
+		clone := li.DOM.Clone()
+		clone.Find("time").Remove()
+		text := strings.Join(strings.Fields(clone.Text()), " ")
+		if text == "" {
+			return
+		}
+		if timestamp != "" {
+			text = fmt.Sprintf("%s – %s", timestamp, text)
+		}
+		events = append(events, text)
+	})
+	if len(events) == 0 {
+		return ""
+	}
+	if oldestFirst {
+		slices.Reverse(events)
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString("## Key events\n\n")
+	for _, event := range events {
+		builder.WriteString(fmt.Sprintf("* %s\n", event))
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// parseGuardianLiveBlog renders each entry of a live blog as a section with
+// its timestamp and title as the heading. Entries are listed newest first as
+// on the page unless oldestFirst is set.
+func parseGuardianLiveBlog(e *colly.HTMLElement, oldestFirst bool) string {
+	entries := make([]string, 0)
+	e.ForEach("article[id^=block-]", func(_ int, block *colly.HTMLElement) {
+		heading := parseGuardianTimestamp(block)
+		title := strings.TrimSpace(block.DOM.Find("header h2").First().Text())
+		switch {
+		case heading == "":
+			heading = title
+		case title != "":
+			heading = fmt.Sprintf("%s – %s", heading, title)
+		}
+
+		builder := strings.Builder{}
+		if heading != "" {
+			builder.WriteString(fmt.Sprintf("## %s\n\n", heading))
+		}
+		builder.WriteString(parseGuardianContent(block))
+		entries = append(entries, builder.String())
+	})
+	if oldestFirst {
+		slices.Reverse(entries)
+	}
+	return strings.Join(entries, "")
+}
+
+// parseGuardianTimestamp returns the timestamp of the first <time> element
+// of a live blog entry, formatted as "2006-01-02 15:04 UTC". The visible
+// text of the element is used when its datetime cannot be parsed.
+func parseGuardianTimestamp(e *colly.HTMLElement) string {
+	timeElement := e.DOM.Find("time").First()
+	if datetime, ok := timeElement.Attr("datetime"); ok {
+		if parsed, err := time.Parse(time.RFC3339, datetime); err == nil {
+			return parsed.UTC().Format("2006-01-02 15:04 UTC")
+		}
+	}
+	return strings.TrimSpace(timeElement.Text())
+}
@@ -1011,3 +1011,252 @@ func TestGuardianScraper_ScrapeFilename_UnicodeCharacters(t *testing.T) {
 		t.Errorf("ScrapeFilename() = %q, want %q", result, expected)
 	}
 }
+
+func TestGuardianScraper_ScrapeArticle_AuthorsAndDates(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<meta property="article:published_time" content="2024-05-01T06:00:00.000Z">
+	<meta property="article:modified_time" content="2024-05-01T09:30:00.000Z">
+</head>
+<body>
+	<h1>Title</h1>
+	<div data-gu-name="meta">
+		<address aria-label="Contributor info">
+			<a rel="author" href="/profile/jane-doe">Jane Doe</a> and
+			<a rel="author" href="/profile/john-smith">John Smith</a>
+		</address>
+	</div>
+	<div class="article-body-commercial-selector">
+		<p>Body</p>
+		<p>By <a rel="author" href="/profile/jane-doe">Jane Doe</a></p>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\ndate: \"2024-05-01T06:00:00.000Z\"\nupdated: \"2024-05-01T09:30:00.000Z\"\nauthors:\n  - \"Jane Doe\"\n  - \"John Smith\"\n---\n\n# Title\n\n"
+	if !strings.HasPrefix(result, expected) {
+		t.Errorf("expected front matter %q, got: %q", expected, result)
+	}
+}
+
+func TestGuardianScraper_ScrapeArticle_RichBody(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<div class="article-body-commercial-selector">
+		<p>Read <a href="/world/2024/other-story">the <strong>other</strong> story</a> and <em>this</em>.</p>
+		<h2>A subheading</h2>
+		<blockquote>
+			<p>First quoted paragraph.</p>
+			<p>Second quoted paragraph.</p>
+		</blockquote>
+		<ul>
+			<li><p>First point</p></li>
+			<li>Second point</li>
+		</ul>
+		<div class="ad-slot-container">
+			<p>Wrapped paragraph.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"Read [the **other** story](" + server.URL + "/world/2024/other-story) and *this*.\n\n" +
+		"## A subheading\n\n" +
+		"> First quoted paragraph.\n>\n> Second quoted paragraph.\n\n" +
+		"* First point\n* Second point\n\n" +
+		"Wrapped paragraph.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestGuardianScraper_ScrapeArticle_Figures(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<div data-gu-name="media">
+		<figure>
+			<picture><img src="https://i.guim.co.uk/img/media/lead.jpg" alt="Lead image"></picture>
+			<figcaption><span>Protesters gather in the square.</span> Photograph: Agency</figcaption>
+		</figure>
+	</div>
+	<div class="article-body-commercial-selector">
+		<figure>
+			<picture><img src="/img/inline.jpg" alt="Inline image"></picture>
+		</figure>
+		<figure data-spacefinder-role="richLink">
+			<aside><a href="/world/related">Related story</a></aside>
+		</figure>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"![Lead image](https://i.guim.co.uk/img/media/lead.jpg)\n\n" +
+		"*Protesters gather in the square. Photograph: Agency*\n\n" +
+		"![Inline image](" + server.URL + "/img/inline.jpg)\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+const guardianLiveBlogTestPage = `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Election live: results as they happen</h1>
+	<div id="key-events-carousel">
+		<ul>
+			<li><a href="?page=with:block-2#block-2"><time datetime="2024-07-05T09:30:00.000Z">2h ago</time><span>Winner declared</span></a></li>
+			<li><a href="?page=with:block-1#block-1"><time datetime="2024-07-05T08:00:00.000Z">4h ago</time><span>Polls close</span></a></li>
+		</ul>
+	</div>
+	<div id="liveblog-body" class="article-body-commercial-selector">
+		<article id="block-2">
+			<header><a href="?page=with:block-2#block-2"><time datetime="2024-07-05T09:30:00.000Z">10.30 BST</time></a><h2>Winner declared</h2></header>
+			<div><p>The result is in.</p></div>
+			<footer><button>Share</button></footer>
+		</article>
+		<article id="block-3">
+			<header><time datetime="2024-07-05T09:00:00.000Z">10.00 BST</time></header>
+			<p>Counting continues.</p>
+		</article>
+		<article id="block-1">
+			<header><time datetime="2024-07-05T08:00:00.000Z">09.00 BST</time><h2>Polls close</h2></header>
+			<p>Polls have now closed.</p>
+		</article>
+	</div>
+</body>
+</html>`
+
+func TestGuardianScraper_ScrapeArticle_LiveBlogNewestFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(guardianLiveBlogTestPage))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Election live: results as they happen\n\n" +
+		"## Key events\n\n" +
+		"* 2024-07-05 09:30 UTC – Winner declared\n" +
+		"* 2024-07-05 08:00 UTC – Polls close\n\n" +
+		"## 2024-07-05 09:30 UTC – Winner declared\n\nThe result is in.\n\n" +
+		"## 2024-07-05 09:00 UTC\n\nCounting continues.\n\n" +
+		"## 2024-07-05 08:00 UTC – Polls close\n\nPolls have now closed.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestGuardianScraper_ScrapeArticle_LiveBlogOldestFirst(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(guardianLiveBlogTestPage))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{OldestFirst: true}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Election live: results as they happen\n\n" +
+		"## Key events\n\n" +
+		"* 2024-07-05 08:00 UTC – Polls close\n" +
+		"* 2024-07-05 09:30 UTC – Winner declared\n\n" +
+		"## 2024-07-05 08:00 UTC – Polls close\n\nPolls have now closed.\n\n" +
+		"## 2024-07-05 09:00 UTC\n\nCounting continues.\n\n" +
+		"## 2024-07-05 09:30 UTC – Winner declared\n\nThe result is in.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestGuardianScraper_ScrapeArticle_LiveBlogUnparsableTime(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Live</h1>
+	<div id="liveblog-body">
+		<article id="block-1">
+			<header><time>10.00 BST</time><h2>Update</h2></header>
+			<p>Text.</p>
+		</article>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &GuardianScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "## 10.00 BST – Update\n\nText.\n\n") {
+		t.Errorf("expected visible time to be used, got: %q", result)
+	}
+}
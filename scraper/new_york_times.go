@@ -1,30 +1,105 @@
 package scraper
 
 import (
+	"encoding/json"
+	"errors"
 	"fmt"
+	"slices"
 	"strings"
 
 	"github.com/gocolly/colly"
 )
 
+// ErrContentTruncated is returned when only a part of an article is
+// available, typically because the rest is behind a paywall.
+var ErrContentTruncated = errors.New("article content is truncated")
+
+// newYorkTimesMinWordRatio is the minimum ratio of the scraped word count to
+// the word count declared in the JSON-LD data for an article to be
+// considered complete.
+const newYorkTimesMinWordRatio = 0.8
+
+// newYorkTimesBodySelector selects the containers of an article body.
+const newYorkTimesBodySelector = "section[name=articleBody], div.article-content-container"
+
 type NewYorkTimesScraper struct {
 }
 
+// newYorkTimesLinkedData is the subset of the JSON-LD NewsArticle data
+// embedded in article pages.
+type newYorkTimesLinkedData struct {
+	Type          any             `json:"@type"`
+	Description   string          `json:"description"`
+	Author        json.RawMessage `json:"author"`
+	DatePublished string          `json:"datePublished"`
+	DateModified  string          `json:"dateModified"`
+	WordCount     int             `json:"wordCount"`
+}
+
 // ScrapeArticle scrapes the article content from the specified URL
-// and returns markdown in a string
+// and returns markdown in a string. ErrContentTruncated is returned
+// when the article is behind a paywall or only partially available.
 func (g *NewYorkTimesScraper) ScrapeArticle(url string) (string, error) {
 	c := colly.NewCollector()
 
 	var markdown string
+	var linkedData *newYorkTimesLinkedData
+	metadata := frontMatter{}
+	authors := make([]string, 0)
+	paywalled := false
+	wordCount := 0
+
+	// embedded article data
+	c.OnHTML("script[type='application/ld+json']", func(e *colly.HTMLElement) {
+		if linkedData != nil {
+			return
+		}
+		linkedData = parseNewYorkTimesLinkedData(e.Text)
+	})
+
+	// byline
+	c.OnHTML("[data-testid=byline] a[href*='/by/']", func(e *colly.HTMLElement) {
+		author := strings.TrimSpace(e.Text)
+		if author != "" && !slices.Contains(authors, author) {
+			authors = append(authors, author)
+		}
+	})
+
+	// publication date
+	c.OnHTML("meta[property='article:published_time']", func(e *colly.HTMLElement) {
+		metadata.set("date", e.Attr("content"))
+	})
+
+	// paywall overlay
+	c.OnHTML("#gateway-content", func(_ *colly.HTMLElement) {
+		paywalled = true
+	})
 
 	// title
 	c.OnHTML("h1", func(e *colly.HTMLElement) {
 		markdown += fmt.Sprintf("# %s\n\n", e.Text)
 	})
 
+	// summary
+	c.OnHTML("p#article-summary", func(e *colly.HTMLElement) {
+		markdown += fmt.Sprintf("## %s\n\n", strings.TrimSpace(e.Text))
+	})
+
+	// lead image
+	c.OnHTML("header figure", func(e *colly.HTMLElement) {
+		markdown += renderMarkdownFigure(e, nil)
+	})
+
 	// article body
-	c.OnHTML("div.article-content-container p", func(e *colly.HTMLElement) {
-		markdown += fmt.Sprintf("%s\n\n", e.Text)
+	c.OnHTML(newYorkTimesBodySelector, func(e *colly.HTMLElement) {
+		// a container nested in another is rendered with the outermost one
+		if e.DOM.ParentsFiltered(newYorkTimesBodySelector).Length() > 0 {
+			return
+		}
+		e.ForEach("p", func(_ int, p *colly.HTMLElement) {
+			wordCount += len(strings.Fields(p.Text))
+		})
+		markdown += parseNewYorkTimesContent(e)
 	})
 
 	err := c.Visit(url)
@@ -32,7 +107,27 @@ func (g *NewYorkTimesScraper) ScrapeArticle(url string) (string, error) {
 		return "", err
 	}
 
-	return markdown, nil
+	if linkedData != nil {
+		for _, author := range parseNewYorkTimesAuthors(linkedData.Author) {
+			if !slices.Contains(authors, author) {
+				authors = append(authors, author)
+			}
+		}
+		metadata.set("date", linkedData.DatePublished)
+		metadata.set("updated", linkedData.DateModified)
+		metadata.set("description", linkedData.Description)
+	}
+	metadata.setList("authors", authors)
+
+	if paywalled {
+		return "", fmt.Errorf("%w: the article is behind a paywall", ErrContentTruncated)
+	}
+	if linkedData != nil && linkedData.WordCount > 0 &&
+		float64(wordCount) < float64(linkedData.WordCount)*newYorkTimesMinWordRatio {
+		return "", fmt.Errorf("%w: scraped %d of %d words", ErrContentTruncated, wordCount, linkedData.WordCount)
+	}
+
+	return metadata.String() + markdown, nil
 }
 
 func (g *NewYorkTimesScraper) ScrapeTitle(url string) (string, error) {
@@ -61,3 +156,111 @@ func (g *NewYorkTimesScraper) ScrapeFilename(url string) (string, error) {
 
 	return generateFileNameFromTitle(title), nil
 }
+
+// parseNewYorkTimesLinkedData decodes a JSON-LD script and returns its
+// NewsArticle object. The script may contain a single object or an array of
+// objects. It returns nil when no NewsArticle is found.
+func parseNewYorkTimesLinkedData(text string) *newYorkTimesLinkedData {
+	candidates := make([]newYorkTimesLinkedData, 0)
+	var single newYorkTimesLinkedData
+	if err := json.Unmarshal([]byte(text), &single); err == nil {
+		candidates = append(candidates, single)
+	} else if err := json.Unmarshal([]byte(text), &candidates); err != nil {
+		return nil
+	}
+
+	for _, candidate := range candidates {
+		switch t := candidate.Type.(type) {
+		case string:
+			if strings.HasSuffix(t, "NewsArticle") {
+				return &candidate
+			}
+		case []any:
+			for _, v := range t {
+				if s, ok := v.(string); ok && strings.HasSuffix(s, "NewsArticle") {
+					return &candidate
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// parseNewYorkTimesAuthors returns the names of the authors of JSON-LD data,
+// which may be a single object or an array of objects.
+func parseNewYorkTimesAuthors(raw json.RawMessage) []string {
+	type person struct {
+		Name string `json:"name"`
+	}
+	if len(raw) == 0 {
+		return nil
+	}
+	people := make([]person, 0)
+	var single person
+	if err := json.Unmarshal(raw, &single); err == nil {
+		people = append(people, single)
+	} else if err := json.Unmarshal(raw, &people); err != nil {
+		return nil
+	}
+
+	names := make([]string, 0, len(people))
+	for _, p := range people {
+		if name := strings.TrimSpace(p.Name); name != "" {
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+func parseNewYorkTimesContent(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+
+	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
+		if !child.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+
+		switch child.Name {
+		case "h2":
+			builder.WriteString(fmt.Sprintf("## %s\n\n", strings.TrimSpace(child.Text)))
+		case "h3":
+			builder.WriteString(fmt.Sprintf("### %s\n\n", strings.TrimSpace(child.Text)))
+		case "p":
+			text := strings.TrimSpace(renderMarkdownInline(child))
+			if text != "" {
+				builder.WriteString(fmt.Sprintf("%s\n\n", text))
+			}
+		case "ul":
+			builder.WriteString(renderMarkdownList(child, false))
+		case "ol":
+			builder.WriteString(renderMarkdownList(child, true))
+		case "blockquote":
+			builder.WriteString(parseNewYorkTimesQuote(child))
+		case "figure":
+			builder.WriteString(renderMarkdownFigure(child, nil))
+		case "div":
+			if child.Attr("data-testid") == "pullquote" {
+				builder.WriteString(parseNewYorkTimesQuote(child))
+				return
+			}
+			// skip advertisements
+			if child.DOM.HasClass("ad") || strings.HasPrefix(child.Attr("id"), "ad-") {
+				return
+			}
+			// paragraphs are grouped in companion column containers
+			builder.WriteString(parseNewYorkTimesContent(child))
+		}
+	})
+
+	return builder.String()
+}
+
+// parseNewYorkTimesQuote renders a block quote or pull quote as a markdown
+// block quote.
+func parseNewYorkTimesQuote(e *colly.HTMLElement) string {
+	text := strings.Join(strings.Fields(e.Text), " ")
+	if text == "" {
+		return ""
+	}
+	return fmt.Sprintf("> %s\n\n", text)
+}
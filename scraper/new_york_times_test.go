@@ -1,6 +1,7 @@
 package scraper
 
 import (
+	"errors"
 	"net/http"
 	"net/http/httptest"
 	"strings"
@@ -824,3 +825,248 @@ func TestNewYorkTimesScraper_ScrapeFilename_UnicodeCharacters(t *testing.T) {
 		t.Errorf("ScrapeFilename() = %q, want %q", result, expected)
 	}
 }
+
+func TestNewYorkTimesScraper_ScrapeArticle_LinkedDataMetadata(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<script type="application/ld+json">{"@context":"https://schema.org","@type":"WebSite","name":"The New York Times"}</script>
+	<script type="application/ld+json">[{"@context":"https://schema.org","@type":"NewsArticle","headline":"Title","description":"A short summary.","author":[{"@type":"Person","name":"Jane Doe"},{"@type":"Person","name":"John Smith"}],"datePublished":"2024-05-01T09:00:00.000Z","dateModified":"2024-05-02T10:00:00.000Z","wordCount":4}]</script>
+</head>
+<body>
+	<h1>Title</h1>
+	<div data-testid="byline"><a href="/by/jane-doe">Jane Doe</a></div>
+	<section name="articleBody">
+		<p>One two three four.</p>
+	</section>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\ndate: \"2024-05-01T09:00:00.000Z\"\nupdated: \"2024-05-02T10:00:00.000Z\"\ndescription: \"A short summary.\"\nauthors:\n  - \"Jane Doe\"\n  - \"John Smith\"\n---\n\n" +
+		"# Title\n\nOne two three four.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_StructuredBody(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<article>
+		<header>
+			<h1>Title</h1>
+			<p id="article-summary">The summary of the article.</p>
+			<figure>
+				<img src="https://static01.nyt.com/images/lead.jpg" alt="Lead">
+				<figcaption><span>A crowd in the park.</span> <span>Credit: Photographer</span></figcaption>
+			</figure>
+		</header>
+		<section name="articleBody">
+			<div class="StoryBodyCompanionColumn">
+				<div>
+					<p>Read <a href="/2024/05/01/other.html">the <em>other</em> story</a>.</p>
+				</div>
+			</div>
+			<div id="ad-1"><p>Advertisement</p></div>
+			<h2>A subheading</h2>
+			<div data-testid="pullquote">“A memorable quote.”</div>
+			<figure>
+				<img src="/images/inline.jpg" alt="Inline">
+			</figure>
+		</section>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"## The summary of the article.\n\n" +
+		"![Lead](https://static01.nyt.com/images/lead.jpg)\n\n*A crowd in the park. Credit: Photographer*\n\n" +
+		"Read [the *other* story](" + server.URL + "/2024/05/01/other.html).\n\n" +
+		"## A subheading\n\n" +
+		"> “A memorable quote.”\n\n" +
+		"![Inline](" + server.URL + "/images/inline.jpg)\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_PaywallOverlay(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<section name="articleBody">
+		<p>The first paragraph is free.</p>
+	</section>
+	<div id="gateway-content"><p>Subscribe to continue reading.</p></div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if !errors.Is(err, ErrContentTruncated) {
+		t.Fatalf("expected ErrContentTruncated, got: %v", err)
+	}
+	if result != "" {
+		t.Errorf("expected no content for paywalled article, got: %q", result)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_TruncatedContent(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<script type="application/ld+json">{"@type":"NewsArticle","wordCount":1200}</script>
+</head>
+<body>
+	<h1>Title</h1>
+	<section name="articleBody">
+		<p>Only the teaser is available.</p>
+	</section>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	_, err := scraper.ScrapeArticle(server.URL)
+
+	if !errors.Is(err, ErrContentTruncated) {
+		t.Fatalf("expected ErrContentTruncated, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "scraped 5 of 1200 words") {
+		t.Errorf("expected word counts in error message, got: %v", err)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_NestedBodyContainers(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<script type="application/ld+json">{"@type":"NewsArticle","wordCount":4}</script>
+</head>
+<body>
+	<h1>Title</h1>
+	<section name="articleBody"><div class="article-content-container"><p>Hello world.</p></div></section>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	_, err := scraper.ScrapeArticle(server.URL)
+
+	// the words of the nested container are counted once
+	if !errors.Is(err, ErrContentTruncated) {
+		t.Fatalf("expected ErrContentTruncated, got: %v", err)
+	}
+	if !strings.Contains(err.Error(), "scraped 2 of 4 words") {
+		t.Errorf("expected words to be counted once, got: %v", err)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_NestedBodyContainersRenderedOnce(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1>Title</h1>
+	<section name="articleBody"><div class="article-content-container"><p>Hello world.</p></div></section>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if count := strings.Count(result, "Hello world."); count != 1 {
+		t.Errorf("expected the paragraph once, got %d times: %q", count, result)
+	}
+}
+
+func TestNewYorkTimesScraper_ScrapeArticle_InvalidLinkedDataIgnored(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head>
+	<title>Test</title>
+	<script type="application/ld+json">{not json</script>
+</head>
+<body>
+	<h1>Title</h1>
+	<section name="articleBody"><p>Body.</p></section>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &NewYorkTimesScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\nBody.\n\n"
+	if result != expected {
+		t.Errorf("ScrapeArticle() = %q, want %q", result, expected)
+	}
+}
@@ -12,8 +12,17 @@ type OllamaScraper struct {
 }
 
 // ScrapeArticle scrapes the article content from the specified URL
-// and returns markdown in a string
+// and returns markdown in a string. Model pages of the library are rendered
+// with their metadata, variants and readme.
 func (o *OllamaScraper) ScrapeArticle(url string) (string, error) {
+	if isOllamaLibraryURL(url) {
+		model, err := o.scrapeModel(url)
+		if err != nil {
+			return "", err
+		}
+		return model.markdown(), nil
+	}
+
 	collector := colly.NewCollector()
 
 	var markdown string
@@ -40,6 +49,14 @@ func (o *OllamaScraper) ScrapeArticle(url string) (string, error) {
 }
 
 func (o *OllamaScraper) ScrapeTitle(url string) (string, error) {
+	if isOllamaLibraryURL(url) {
+		model, err := o.scrapeModel(url)
+		if err != nil {
+			return "", err
+		}
+		return model.Name, nil
+	}
+
 	collector := colly.NewCollector()
 
 	var title string
@@ -56,8 +73,18 @@ func (o *OllamaScraper) ScrapeTitle(url string) (string, error) {
 	return title, nil
 }
 
+// ScrapeFilename returns the basename of the URL of a blog post. For a model
+// page of the library, it is the name of the model followed by the tag, e.g.
+// llama3.2_3b, as colons are not allowed in filenames on Windows.
 func (o *OllamaScraper) ScrapeFilename(url string) (string, error) {
-	return getBasenameFromURL(url), nil
+	name, tag := getOllamaModel(url)
+	if name == "" {
+		return getBasenameFromURL(url), nil
+	}
+	if tag == "latest" {
+		return removeNonFilenameChars(name), nil
+	}
+	return removeNonFilenameChars(name + "_" + tag), nil
 }
 
 func parseOllamaContent(e *colly.HTMLElement) string {
@@ -97,6 +124,8 @@ func parseOllamaContent(e *colly.HTMLElement) string {
 			builder.WriteString(parseOllamaList(child, true))
 		case "pre":
 			builder.WriteString(parseOllamaCodeBlock(child))
+		case "table":
+			builder.WriteString(renderMarkdownTable(child, parseOllamaInline))
 		case "blockquote":
 			text := strings.TrimSpace(child.Text)
 			if text != "" {
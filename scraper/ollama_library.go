@@ -0,0 +1,291 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+var (
+	// ollamaSizePattern matches the download size of a variant, e.g. 2.0GB.
+	ollamaSizePattern = regexp.MustCompile(`^\d+(\.\d+)?\s?[KMGT]?B$`)
+	// ollamaContextPattern matches the context window of a variant, e.g. 128K
+	// or 128K context window.
+	ollamaContextPattern = regexp.MustCompile(`^(\d+(\.\d+)?[KM])( context( window)?)?$`)
+	// ollamaParametersPattern matches the parameter count in a tag, e.g. 3b,
+	// 1.5b, 8x7b, 135m or e2b.
+	ollamaParametersPattern = regexp.MustCompile(`(?i)^(e?\d+(\.\d+)?|\d+x\d+(\.\d+)?)[bm]$`)
+	// ollamaQuantizationPattern matches the quantisation in a tag, e.g.
+	// q4_K_M, q8_0 or fp16.
+	ollamaQuantizationPattern = regexp.MustCompile(`(?i)^(q\d+(_[a-z0-9]+)*|iq\d+(_[a-z0-9]+)*|fp16|fp32|bf16|int4|int8)$`)
+)
+
+// OllamaModel is the structured content of a model page of the Ollama
+// library, e.g. https://ollama.com/library/llama3.2.
+type OllamaModel struct {
+	Name         string   `json:"name"`
+	Description  string   `json:"description,omitempty"`
+	Capabilities []string `json:"capabilities,omitempty"`
+	// Parameters are the parameter counts the model is available in, e.g. 1b
+	// and 3b.
+	Parameters  []string        `json:"parameters,omitempty"`
+	Downloads   string          `json:"downloads,omitempty"`
+	Updated     string          `json:"updated,omitempty"`
+	PullCommand string          `json:"pull_command"`
+	License     string          `json:"license,omitempty"`
+	Variants    []OllamaVariant `json:"variants,omitempty"`
+	// Readme is the description of the model in markdown.
+	Readme string `json:"readme"`
+}
+
+// OllamaVariant is a tag of a model, which is pulled as name:tag.
+type OllamaVariant struct {
+	Tag          string `json:"tag"`
+	Size         string `json:"size,omitempty"`
+	Parameters   string `json:"parameters,omitempty"`
+	Quantization string `json:"quantization,omitempty"`
+	Context      string `json:"context,omitempty"`
+	Input        string `json:"input,omitempty"`
+}
+
+// ScrapeData scrapes the model page of the Ollama library of the specified
+// URL and returns it as an OllamaModel. Blog posts are not supported.
+func (o *OllamaScraper) ScrapeData(url string) (any, error) {
+	if !isOllamaLibraryURL(url) {
+		return nil, fmt.Errorf("structured data is only available for model pages of the library: %s", url)
+	}
+	return o.scrapeModel(url)
+}
+
+// scrapeModel scrapes a model page of the library. Variants are listed as
+// links to name:tag, each in a row with its size, context window and input
+// types. The parameter count and the quantisation of a variant are taken
+// from its tag or, for the variant of the page, from the layers of the
+// model.
+func (o *OllamaScraper) scrapeModel(pageURL string) (*OllamaModel, error) {
+	name, tag := getOllamaModel(pageURL)
+	model := &OllamaModel{Name: name}
+
+	collector := colly.NewCollector()
+
+	collector.OnHTML("html", func(e *colly.HTMLElement) {
+		if title := e.DOM.Find("[x-test-model-title]").First(); title.Length() > 0 {
+			model.Name = strings.TrimSpace(title.AttrOr("title", title.Text()))
+		}
+
+		model.Description = strings.TrimSpace(e.DOM.Find("#summary-content").First().Text())
+		if model.Description == "" {
+			model.Description = strings.TrimSpace(e.DOM.Find("meta[name='description']").AttrOr("content", ""))
+		}
+
+		e.DOM.Find("[x-test-capability]").Each(func(_ int, s *goquery.Selection) {
+			model.Capabilities = append(model.Capabilities, strings.TrimSpace(s.Text()))
+		})
+		e.DOM.Find("[x-test-size]").Each(func(_ int, s *goquery.Selection) {
+			model.Parameters = append(model.Parameters, strings.TrimSpace(s.Text()))
+		})
+		model.Downloads = strings.TrimSpace(e.DOM.Find("[x-test-pull-count]").First().Text())
+		model.Updated = strings.TrimSpace(e.DOM.Find("[x-test-updated]").First().Text())
+
+		// the command to run the model is shown in a read-only input
+		command := e.DOM.Find("input[value^='ollama ']").First().AttrOr("value", "")
+		if fields := strings.Fields(command); len(fields) >= 3 {
+			model.PullCommand = "ollama pull " + fields[2]
+		}
+
+		model.License = firstLine(ollamaLabelledValue(e.DOM, "license"))
+
+		model.Variants = parseOllamaVariants(e, model.Name)
+		for i := range model.Variants {
+			variant := &model.Variants[i]
+			if variant.Tag != model.Name+":"+tag {
+				continue
+			}
+			if variant.Parameters == "" {
+				variant.Parameters = ollamaLabelledValue(e.DOM, "parameters")
+			}
+			if variant.Quantization == "" {
+				variant.Quantization = ollamaLabelledValue(e.DOM, "quantization")
+			}
+		}
+
+		readme := e.DOM.Find("#readme #display").First()
+		if readme.Length() == 0 {
+			readme = e.DOM.Find("#readme").First()
+		}
+		if readme.Length() > 0 {
+			element := colly.NewHTMLElementFromSelectionNode(e.Response, readme, readme.Get(0), 0)
+			model.Readme = parseOllamaContent(element)
+		}
+	})
+
+	err := collector.Visit(pageURL)
+	if err != nil {
+		return nil, err
+	}
+
+	if model.PullCommand == "" && model.Name != "" {
+		model.PullCommand = "ollama pull " + model.Name
+	}
+
+	return model, nil
+}
+
+// markdown renders the model as markdown with its metadata in front matter,
+// the variants in a table and the readme.
+func (m *OllamaModel) markdown() string {
+	metadata := frontMatter{}
+	metadata.set("pull_command", m.PullCommand)
+	metadata.set("license", m.License)
+	metadata.set("downloads", m.Downloads)
+	metadata.set("updated", m.Updated)
+	metadata.setList("capabilities", m.Capabilities)
+	metadata.setList("parameters", m.Parameters)
+
+	builder := strings.Builder{}
+	builder.WriteString(metadata.String())
+	if m.Name != "" {
+		builder.WriteString(fmt.Sprintf("# %s\n\n", m.Name))
+	}
+	if m.Description != "" {
+		builder.WriteString(fmt.Sprintf("%s\n\n", m.Description))
+	}
+
+	if len(m.Variants) > 0 {
+		builder.WriteString("## Models\n\n")
+		builder.WriteString("| Tag | Size | Parameters | Quantization | Context | Input |\n")
+		builder.WriteString("| --- | --- | --- | --- | --- | --- |\n")
+		for _, v := range m.Variants {
+			cells := []string{v.Tag, v.Size, v.Parameters, v.Quantization, v.Context, v.Input}
+			for i, cell := range cells {
+				cells[i] = strings.ReplaceAll(cell, "|", "\\|")
+			}
+			builder.WriteString(fmt.Sprintf("| %s |\n", strings.Join(cells, " | ")))
+		}
+		builder.WriteString("\n")
+	}
+
+	if m.Readme != "" {
+		builder.WriteString("## Readme\n\n")
+		builder.WriteString(m.Readme)
+	}
+
+	return builder.String()
+}
+
+// parseOllamaVariants returns the variants of a model listed on its page or
+// its page of tags. A variant may be listed more than once, e.g. for small
+// and large screens, in which case the details are merged.
+func parseOllamaVariants(e *colly.HTMLElement, name string) []OllamaVariant {
+	var variants []OllamaVariant
+	indexes := make(map[string]int)
+
+	e.ForEach("a[href]", func(_ int, a *colly.HTMLElement) {
+		href, err := url.Parse(a.Attr("href"))
+		if err != nil {
+			return
+		}
+		path, found := strings.CutPrefix(href.Path, "/library/")
+		if !found {
+			return
+		}
+		// links to the layers of a variant are below its path
+		modelName, tag, found := strings.Cut(path, ":")
+		if !found || modelName != name || tag == "" || strings.Contains(tag, "/") {
+			return
+		}
+
+		variant := OllamaVariant{Tag: name + ":" + tag}
+		for _, piece := range strings.Split(tag, "-") {
+			switch {
+			case ollamaParametersPattern.MatchString(piece):
+				variant.Parameters = piece
+			case ollamaQuantizationPattern.MatchString(piece):
+				variant.Quantization = piece
+			}
+		}
+		a.DOM.Parent().Children().Each(func(_ int, cell *goquery.Selection) {
+			if cell.IsSelection(a.DOM) {
+				return
+			}
+			for _, text := range strings.Split(cell.Text(), "·") {
+				text = strings.Join(strings.Fields(text), " ")
+				switch {
+				case text == "" || strings.HasSuffix(text, " ago"):
+				case ollamaSizePattern.MatchString(text):
+					variant.Size = text
+				case ollamaContextPattern.MatchString(text):
+					variant.Context = ollamaContextPattern.FindStringSubmatch(text)[1]
+				case variant.Input == "":
+					variant.Input = text
+				}
+			}
+		})
+
+		index, ok := indexes[variant.Tag]
+		if !ok {
+			indexes[variant.Tag] = len(variants)
+			variants = append(variants, variant)
+			return
+		}
+		existing := &variants[index]
+		if existing.Size == "" {
+			existing.Size = variant.Size
+		}
+		if existing.Context == "" {
+			existing.Context = variant.Context
+		}
+		if existing.Input == "" {
+			existing.Input = variant.Input
+		}
+	})
+
+	return variants
+}
+
+// ollamaLabelledValue returns the value next to a label, such as the
+// "parameters" label of the layers of a model followed by "3.21B". It
+// returns an empty string if the label is not found.
+func ollamaLabelledValue(sel *goquery.Selection, label string) string {
+	value := ""
+	sel.Find("main *").EachWithBreak(func(_ int, s *goquery.Selection) bool {
+		if s.Children().Length() > 0 || !strings.EqualFold(strings.TrimSpace(s.Text()), label) {
+			return true
+		}
+		value = strings.TrimSpace(s.Next().Text())
+		return value == ""
+	})
+	return value
+}
+
+// isOllamaLibraryURL returns true if the URL is of a model page of the
+// library, e.g. https://ollama.com/library/llama3.2.
+func isOllamaLibraryURL(pageURL string) bool {
+	name, _ := getOllamaModel(pageURL)
+	return name != ""
+}
+
+// getOllamaModel returns the name of the model and the tag of a library URL
+// such as https://ollama.com/library/llama3.2:3b or
+// https://ollama.com/library/llama3.2/tags. The tag is latest if the URL
+// does not specify one.
+func getOllamaModel(pageURL string) (string, string) {
+	parsed, err := url.Parse(pageURL)
+	if err != nil {
+		return "", ""
+	}
+	path, found := strings.CutPrefix(parsed.Path, "/library/")
+	if !found {
+		return "", ""
+	}
+	path, _, _ = strings.Cut(path, "/")
+	name, tag, found := strings.Cut(path, ":")
+	if !found || tag == "" {
+		tag = "latest"
+	}
+	return name, tag
+}
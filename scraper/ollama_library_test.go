@@ -0,0 +1,240 @@
+package scraper
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// ollamaLibraryPage is a minimal model page of the Ollama library.
+const ollamaLibraryPage = `<!DOCTYPE html>
+<html>
+<head>
+  <title>llama3.2</title>
+  <meta name="description" content="Meta's Llama 3.2 goes small.">
+</head>
+<body>
+  <main>
+    <div>
+      <h1 x-test-model-title title="llama3.2"><span>llama3.2</span></h1>
+      <h2><span id="summary-content">Meta's Llama 3.2 goes small with 1B and 3B models.</span></h2>
+      <div>
+        <span x-test-capability>tools</span>
+        <span x-test-size>1b</span>
+        <span x-test-size>3b</span>
+      </div>
+      <p><span x-test-pull-count>20.5M</span> Downloads · Updated <span x-test-updated>1 year ago</span></p>
+      <input class="command" readonly value="ollama run llama3.2">
+    </div>
+    <section>
+      <h2>Models</h2>
+      <div class="hidden sm:grid">
+        <a href="/library/llama3.2:latest">llama3.2:latest</a>
+        <p>2.0GB</p>
+        <p>128K</p>
+        <p>Text</p>
+      </div>
+      <div class="sm:hidden">
+        <a href="/library/llama3.2:latest">llama3.2:latest</a>
+        <p>2.0GB · 128K context window · Text · 1 year ago</p>
+      </div>
+      <div class="hidden sm:grid">
+        <a href="/library/llama3.2:1b-instruct-q8_0">llama3.2:1b-instruct-q8_0</a>
+        <p>1.3GB</p>
+        <p>128K</p>
+        <p>Text</p>
+      </div>
+    </section>
+    <section id="file-explorer">
+      <a href="/library/llama3.2:latest/blobs/dde5aa3fc5ff">
+        <div>model</div>
+        <div>arch llama</div>
+        <div>parameters</div>
+        <div>3.21B</div>
+        <div>quantization</div>
+        <div>Q4_K_M</div>
+      </a>
+      <a href="/library/llama3.2:latest/blobs/fcc5a6bec9da">
+        <div>license</div>
+        <div>LLAMA 3.2 COMMUNITY LICENSE AGREEMENT
+Llama 3.2 Version Release Date: September 25, 2024</div>
+      </a>
+    </section>
+    <div id="readme">
+      <div id="display" class="prose">
+        <p>The Meta Llama 3.2 collection of <strong>multilingual</strong> models.</p>
+        <h2>Sizes</h2>
+        <pre><code class="language-shell">ollama run llama3.2:1b</code></pre>
+        <table>
+          <tr><th>Benchmark</th><th>1B</th></tr>
+          <tr><td>MMLU</td><td>49.3</td></tr>
+        </table>
+      </div>
+    </div>
+  </main>
+</body>
+</html>`
+
+func newOllamaLibraryServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/library/llama3.2" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(ollamaLibraryPage))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestOllamaScraper_ScrapeData_Library(t *testing.T) {
+	server := newOllamaLibraryServer(t)
+
+	scraper := &OllamaScraper{}
+	data, err := scraper.ScrapeData(server.URL + "/library/llama3.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	model := data.(*OllamaModel)
+	if model.Name != "llama3.2" {
+		t.Errorf("expected name llama3.2, got %q", model.Name)
+	}
+	if model.Description != "Meta's Llama 3.2 goes small with 1B and 3B models." {
+		t.Errorf("unexpected description: %q", model.Description)
+	}
+	if strings.Join(model.Capabilities, ",") != "tools" {
+		t.Errorf("expected capabilities [tools], got %v", model.Capabilities)
+	}
+	if strings.Join(model.Parameters, ",") != "1b,3b" {
+		t.Errorf("expected parameters [1b 3b], got %v", model.Parameters)
+	}
+	if model.Downloads != "20.5M" || model.Updated != "1 year ago" {
+		t.Errorf("unexpected downloads %q and updated %q", model.Downloads, model.Updated)
+	}
+	if model.PullCommand != "ollama pull llama3.2" {
+		t.Errorf("expected pull command, got %q", model.PullCommand)
+	}
+	if model.License != "LLAMA 3.2 COMMUNITY LICENSE AGREEMENT" {
+		t.Errorf("expected first line of the license, got %q", model.License)
+	}
+
+	expected := []OllamaVariant{
+		{Tag: "llama3.2:latest", Size: "2.0GB", Parameters: "3.21B", Quantization: "Q4_K_M", Context: "128K", Input: "Text"},
+		{Tag: "llama3.2:1b-instruct-q8_0", Size: "1.3GB", Parameters: "1b", Quantization: "q8_0", Context: "128K", Input: "Text"},
+	}
+	if len(model.Variants) != len(expected) {
+		t.Fatalf("expected %d variants, got %d: %+v", len(expected), len(model.Variants), model.Variants)
+	}
+	for i, variant := range model.Variants {
+		if variant != expected[i] {
+			t.Errorf("variant %d: expected %+v, got %+v", i, expected[i], variant)
+		}
+	}
+
+	if !strings.HasPrefix(model.Readme, "The Meta Llama 3.2 collection of **multilingual** models.\n\n## Sizes\n\n```shell\nollama run llama3.2:1b\n```\n\n") {
+		t.Errorf("unexpected readme: %q", model.Readme)
+	}
+	if !strings.Contains(model.Readme, "| Benchmark | 1B |\n| --- | --- |\n| MMLU | 49.3 |\n\n") {
+		t.Errorf("expected benchmark table in readme, got: %q", model.Readme)
+	}
+}
+
+func TestOllamaScraper_ScrapeArticle_Library(t *testing.T) {
+	server := newOllamaLibraryServer(t)
+
+	scraper := &OllamaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL + "/library/llama3.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.HasPrefix(result, "---\npull_command: \"ollama pull llama3.2\"\nlicense: \"LLAMA 3.2 COMMUNITY LICENSE AGREEMENT\"\n") {
+		t.Errorf("expected metadata in front matter, got: %q", result)
+	}
+	if !strings.Contains(result, "# llama3.2\n\nMeta's Llama 3.2 goes small with 1B and 3B models.\n\n") {
+		t.Errorf("expected name and description, got: %q", result)
+	}
+	expected := "## Models\n\n" +
+		"| Tag | Size | Parameters | Quantization | Context | Input |\n" +
+		"| --- | --- | --- | --- | --- | --- |\n" +
+		"| llama3.2:latest | 2.0GB | 3.21B | Q4_K_M | 128K | Text |\n" +
+		"| llama3.2:1b-instruct-q8_0 | 1.3GB | 1b | q8_0 | 128K | Text |\n\n" +
+		"## Readme\n\n"
+	if !strings.Contains(result, expected) {
+		t.Errorf("expected variants table %q, got: %q", expected, result)
+	}
+}
+
+func TestOllamaScraper_ScrapeTitle_Library(t *testing.T) {
+	server := newOllamaLibraryServer(t)
+
+	scraper := &OllamaScraper{}
+	title, err := scraper.ScrapeTitle(server.URL + "/library/llama3.2")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if title != "llama3.2" {
+		t.Errorf("expected title llama3.2, got %q", title)
+	}
+}
+
+func TestOllamaScraper_ScrapeData_Blog(t *testing.T) {
+	scraper := &OllamaScraper{}
+	_, err := scraper.ScrapeData("https://ollama.com/blog/web-search")
+
+	if err == nil {
+		t.Fatal("expected error for blog post, got nil")
+	}
+}
+
+func TestOllamaScraper_ScrapeFilename_Library(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://ollama.com/library/llama3.2", "llama3.2"},
+		{"https://ollama.com/library/llama3.2:3b", "llama3.2_3b"},
+		{"https://ollama.com/library/llama3.2:1b-instruct-q8_0", "llama3.2_1b-instruct-q8_0"},
+		{"https://ollama.com/library/llama3.2/tags", "llama3.2"},
+	}
+
+	scraper := &OllamaScraper{}
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			result, err := scraper.ScrapeFilename(tt.url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if result != tt.expected {
+				t.Errorf("ScrapeFilename(%q) = %q, want %q", tt.url, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestGetOllamaModel(t *testing.T) {
+	tests := []struct {
+		url  string
+		name string
+		tag  string
+	}{
+		{"https://ollama.com/library/llama3.2", "llama3.2", "latest"},
+		{"https://ollama.com/library/llama3.2:3b", "llama3.2", "3b"},
+		{"https://ollama.com/library/llama3.2/tags", "llama3.2", "latest"},
+		{"https://ollama.com/blog/web-search", "", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			name, tag := getOllamaModel(tt.url)
+			if name != tt.name || tag != tt.tag {
+				t.Errorf("getOllamaModel(%q) = (%q, %q), want (%q, %q)", tt.url, name, tag, tt.name, tt.tag)
+			}
+		})
+	}
+}
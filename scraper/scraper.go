@@ -13,3 +13,11 @@ type ArticleScraper interface {
 	ScrapeTitle(url string) (string, error)
 	ScrapeFilename(url string) (string, error)
 }
+
+// DataScraper is implemented by article scrapers which can also return the
+// scraped article as structured data.
+type DataScraper interface {
+	// ScrapeData scrapes the article from the specified URL and returns a
+	// value to be serialised as JSON
+	ScrapeData(url string) (any, error)
+}
@@ -2,6 +2,7 @@ package scraper
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -9,7 +10,30 @@ import (
 	"golang.org/x/net/html"
 )
 
+// tailscaleCallouts maps the classes of callout boxes to their labels.
+var tailscaleCallouts = map[string]string{
+	"note":      "Note",
+	"info":      "Info",
+	"tip":       "Tip",
+	"important": "Important",
+	"warning":   "Warning",
+	"caution":   "Caution",
+	"danger":    "Danger",
+}
+
 type TailscaleScraper struct {
+	// Tab is the label of the tab, e.g. macOS, of which the instructions are
+	// rendered for tab groups having the tab. All tabs are rendered as
+	// subsections if it is empty.
+	Tab string
+}
+
+// tailscaleOptions holds the options and the state of rendering an article.
+type tailscaleOptions struct {
+	tab string
+	// level is the level of the last heading, under which tabs are rendered
+	// as subsections
+	level int
 }
 
 // ScrapeArticle scrapes the article content from the specified URL
@@ -26,7 +50,7 @@ func (t *TailscaleScraper) ScrapeArticle(url string) (string, error) {
 
 	// article body
 	c.OnHTML("article#main-content > div.ts-prose", func(e *colly.HTMLElement) {
-		markdown += parseTailscaleContent(e)
+		markdown += parseTailscaleContent(e, &tailscaleOptions{tab: t.Tab, level: 1})
 	})
 
 	err := c.Visit(url)
@@ -58,56 +82,66 @@ func (t *TailscaleScraper) ScrapeFilename(url string) (string, error) {
 	return getBasenameFromURL(url), nil
 }
 
-func parseTailscaleContent(e *colly.HTMLElement) string {
+func parseTailscaleContent(e *colly.HTMLElement, opts *tailscaleOptions) string {
 	builder := strings.Builder{}
 
 	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
 		if !child.DOM.Parent().IsSelection(e.DOM) {
 			return
 		}
+		builder.WriteString(parseTailscaleBlock(child, opts))
+	})
 
-		switch child.Name {
-		case "h2":
-			builder.WriteString(fmt.Sprintf("## %s\n\n", parseTailscaleHeading(child)))
-		case "h3":
-			builder.WriteString(fmt.Sprintf("### %s\n\n", parseTailscaleHeading(child)))
-		case "h4":
-			builder.WriteString(fmt.Sprintf("#### %s\n\n", parseTailscaleHeading(child)))
-		case "p":
-			text := strings.TrimSpace(parseTailscaleInline(child))
-			if text != "" {
-				builder.WriteString(fmt.Sprintf("%s\n\n", text))
-			}
-		case "ul":
-			builder.WriteString(parseTailscaleList(child, false))
-		case "ol":
-			builder.WriteString(parseTailscaleList(child, true))
-		case "div":
-			if child.DOM.HasClass("note") {
-				child.ForEach("*", func(_ int, noteChild *colly.HTMLElement) {
-					if !noteChild.DOM.Parent().IsSelection(child.DOM) {
-						return
-					}
-					switch noteChild.Name {
-					case "p":
-						text := strings.TrimSpace(parseTailscaleInline(noteChild))
-						if text != "" {
-							builder.WriteString(fmt.Sprintf("> %s\n\n", text))
-						}
-					case "div":
-						if noteChild.DOM.HasClass("group") && noteChild.DOM.HasClass("relative") && noteChild.DOM.HasClass("overflow-hidden") {
-							builder.WriteString(parseTailscaleCodeBlock(noteChild))
-						}
-					}
-				})
-			} else if child.DOM.HasClass("group") && child.DOM.HasClass("relative") && child.DOM.HasClass("overflow-hidden") {
-				builder.WriteString(parseTailscaleCodeBlock(child))
-			}
-		case "pre":
-			// standalone pre (not inside a div.group wrapper)
+	return builder.String()
+}
+
+// parseTailscaleBlock renders a block element of an article as markdown.
+// Elements which are not blocks are ignored.
+func parseTailscaleBlock(child *colly.HTMLElement, opts *tailscaleOptions) string {
+	builder := strings.Builder{}
+
+	switch child.Name {
+	case "h2":
+		builder.WriteString(fmt.Sprintf("## %s\n\n", parseTailscaleHeading(child)))
+		opts.level = 2
+	case "h3":
+		builder.WriteString(fmt.Sprintf("### %s\n\n", parseTailscaleHeading(child)))
+		opts.level = 3
+	case "h4":
+		builder.WriteString(fmt.Sprintf("#### %s\n\n", parseTailscaleHeading(child)))
+		opts.level = 4
+	case "p":
+		text := strings.TrimSpace(parseTailscaleInline(child))
+		if text != "" {
+			builder.WriteString(fmt.Sprintf("%s\n\n", text))
+		}
+	case "ul":
+		builder.WriteString(parseTailscaleList(child, false, opts))
+	case "ol":
+		builder.WriteString(parseTailscaleList(child, true, opts))
+	case "div", "aside":
+		if label := tailscaleCalloutLabel(child.DOM); label != "" {
+			builder.WriteString(parseTailscaleCallout(child, label, opts))
+		} else if child.DOM.HasClass("group") && child.DOM.HasClass("relative") && child.DOM.HasClass("overflow-hidden") {
 			builder.WriteString(parseTailscaleCodeBlock(child))
+		} else if child.DOM.ChildrenFiltered("[role='tablist']").Length() > 0 {
+			builder.WriteString(parseTailscaleTabs(child, opts))
+		} else {
+			// other containers wrap content such as tables and images
+			builder.WriteString(parseTailscaleContent(child, opts))
 		}
-	})
+	case "table":
+		builder.WriteString(renderMarkdownTable(child, parseTailscaleInline))
+	case "figure":
+		builder.WriteString(renderMarkdownFigure(child, nil))
+	case "img":
+		if image := renderMarkdownImage(child, child.DOM); image != "" {
+			builder.WriteString(fmt.Sprintf("%s\n\n", image))
+		}
+	case "pre":
+		// standalone pre (not inside a div.group wrapper)
+		builder.WriteString(parseTailscaleCodeBlock(child))
+	}
 
 	return builder.String()
 }
@@ -150,59 +184,94 @@ func parseTailscaleHeading(e *colly.HTMLElement) string {
 	return strings.TrimSpace(text)
 }
 
-// parseTailscaleList renders a <ul> or <ol> element as markdown.
-// Each list item's direct children are examined: <p> elements provide the
-// bullet text, and any div.group code block wrappers are emitted as fenced
-// code blocks after the bullet.
-func parseTailscaleList(e *colly.HTMLElement, ordered bool) string {
-	builder := strings.Builder{}
-	index := 0
+// parseTailscaleList renders a <ul> or <ol> element as markdown. Every block
+// of a list item, such as paragraphs, code blocks, notes and nested lists, is
+// indented under the bullet, so that the steps of a procedure stay in one
+// list. Items are separated by blank lines if any item has several blocks.
+func parseTailscaleList(e *colly.HTMLElement, ordered bool, opts *tailscaleOptions) string {
+	number := 1
+	if start, err := strconv.Atoi(e.Attr("start")); err == nil {
+		number = start
+	}
+
+	items := make([]string, 0)
+	loose := false
 	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
 		// only process direct children of this list element
 		if !li.DOM.Parent().IsSelection(e.DOM) {
 			return
 		}
-		index++
-
-		// Collect bullet text from direct <p> children (or the li text when
-		// there are no child block elements).
-		bulletText := ""
-		hasBlockChildren := li.DOM.Children().FilterFunction(func(_ int, s *goquery.Selection) bool {
-			name := goquery.NodeName(s)
-			return name == "p" || name == "div" || name == "ul" || name == "ol"
-		}).Length() > 0
-
-		if hasBlockChildren {
-			li.ForEach("p", func(_ int, p *colly.HTMLElement) {
-				if !p.DOM.Parent().IsSelection(li.DOM) {
-					return
-				}
-				if bulletText == "" {
-					bulletText = strings.TrimSpace(parseTailscaleInline(p))
-				}
-			})
-		} else {
-			bulletText = strings.TrimSpace(parseTailscaleInline(li))
-		}
 
+		marker := "* "
 		if ordered {
-			builder.WriteString(fmt.Sprintf("%d. %s\n", index, bulletText))
-		} else {
-			builder.WriteString(fmt.Sprintf("* %s\n", bulletText))
+			marker = fmt.Sprintf("%d. ", number)
+			number++
 		}
+		indent := strings.Repeat(" ", len(marker))
 
-		// Emit any code blocks that are direct children of this <li>.
-		li.ForEach("div", func(_ int, d *colly.HTMLElement) {
-			if !d.DOM.Parent().IsSelection(li.DOM) {
-				return
-			}
-			if d.DOM.HasClass("group") && d.DOM.HasClass("relative") && d.DOM.HasClass("overflow-hidden") {
-				builder.WriteString(parseTailscaleCodeBlock(d))
+		body := parseTailscaleListItem(li, opts)
+		if strings.Contains(body, "\n\n") {
+			loose = true
+		}
+
+		item := strings.Builder{}
+		for i, line := range strings.Split(body, "\n") {
+			switch {
+			case i == 0:
+				item.WriteString(marker)
+				item.WriteString(line)
+			case line != "":
+				item.WriteString(indent)
+				item.WriteString(line)
 			}
-		})
+			item.WriteString("\n")
+		}
+		items = append(items, item.String())
 	})
-	builder.WriteString("\n")
-	return builder.String()
+
+	separator := ""
+	if loose {
+		separator = "\n"
+	}
+	return strings.Join(items, separator) + "\n"
+}
+
+// parseTailscaleListItem renders the content of a list item. Runs of text
+// and inline elements become paragraphs and other elements are rendered as
+// blocks.
+func parseTailscaleListItem(li *colly.HTMLElement, opts *tailscaleOptions) string {
+	builder := strings.Builder{}
+	inline := strings.Builder{}
+	flush := func() {
+		if text := strings.TrimSpace(inline.String()); text != "" {
+			builder.WriteString(fmt.Sprintf("%s\n\n", text))
+		}
+		inline.Reset()
+	}
+
+	contents := li.DOM.Contents()
+	for i, node := range contents.Nodes {
+		if node.Type == html.ElementNode && isTailscaleBlock(node.Data) {
+			flush()
+			child := colly.NewHTMLElementFromSelectionNode(li.Response, contents.Eq(i), node, i)
+			builder.WriteString(parseTailscaleBlock(child, opts))
+			continue
+		}
+		inline.WriteString(parseTailscaleInlineSelection(li, contents.Eq(i)))
+	}
+	flush()
+
+	return strings.Trim(builder.String(), "\n")
+}
+
+// isTailscaleBlock returns true if an element of the specified name is
+// rendered as a block.
+func isTailscaleBlock(name string) bool {
+	switch name {
+	case "p", "ul", "ol", "div", "aside", "pre", "table", "figure", "h2", "h3", "h4":
+		return true
+	}
+	return false
 }
 
 // parseTailscaleCodeLang extracts the language identifier from a <pre> element's class.
@@ -221,13 +290,19 @@ func parseTailscaleCodeLang(e *colly.HTMLElement) string {
 // preserving <code> as backtick spans and <a> as markdown links.
 // Text nodes are emitted as-is; all other elements fall back to their text content.
 func parseTailscaleInline(e *colly.HTMLElement) string {
+	return parseTailscaleInlineSelection(e, e.DOM.Contents())
+}
+
+// parseTailscaleInlineSelection renders the nodes of a selection as inline
+// markdown.
+func parseTailscaleInlineSelection(e *colly.HTMLElement, contents *goquery.Selection) string {
 	builder := strings.Builder{}
-	for _, node := range e.DOM.Contents().Nodes {
+	for i, node := range contents.Nodes {
 		switch node.Type {
 		case html.TextNode:
 			builder.WriteString(node.Data)
 		case html.ElementNode:
-			sel := e.DOM.FindNodes(node)
+			sel := contents.Eq(i)
 			switch node.Data {
 			case "code":
 				builder.WriteString(fmt.Sprintf("`%s`", sel.Text()))
@@ -239,6 +314,8 @@ func parseTailscaleInline(e *colly.HTMLElement) string {
 				} else {
 					builder.WriteString(linkText)
 				}
+			case "img":
+				builder.WriteString(renderMarkdownImage(e, sel))
 			default:
 				builder.WriteString(sel.Text())
 			}
@@ -277,3 +354,47 @@ func parseTailscaleInlineNodes(nodes []*html.Node) string {
 	}
 	return builder.String()
 }
+
+// tailscaleCalloutLabel returns the label of a callout box, e.g. Warning for
+// <div class="warning"> or <div class="callout callout-warning">. It returns
+// an empty string if the element is not a callout.
+func tailscaleCalloutLabel(sel *goquery.Selection) string {
+	for _, class := range strings.Fields(strings.ToLower(sel.AttrOr("class", ""))) {
+		class = strings.TrimPrefix(class, "callout-")
+		if label, ok := tailscaleCallouts[class]; ok {
+			return label
+		}
+	}
+	return ""
+}
+
+// parseTailscaleCallout renders a callout box as a blockquote starting with
+// its label in bold, e.g.
+//
+//	> **Warning**
+//	>
+//	> Text of the callout.
+func parseTailscaleCallout(e *colly.HTMLElement, label string, opts *tailscaleOptions) string {
+	return quoteMarkdownCallout(label, parseTailscaleContent(e, opts))
+}
+
+// parseTailscaleTabs renders a group of tabs, each of which holds the
+// instructions for a platform. The panel of the tab selected by the tab
+// option is rendered alone if the group has it, otherwise every panel is
+// rendered as a subsection titled by the label of its tab.
+func parseTailscaleTabs(e *colly.HTMLElement, opts *tailscaleOptions) string {
+	tabs := parseAriaTabs(e)
+
+	if opts.tab != "" {
+		for _, tab := range tabs {
+			if strings.EqualFold(tab.label, opts.tab) {
+				return parseTailscaleContent(tab.panel, opts)
+			}
+		}
+	}
+
+	level := min(opts.level+1, 6)
+	return renderMarkdownTabs(tabs, level, func(panel *colly.HTMLElement) string {
+		return parseTailscaleContent(panel, &tailscaleOptions{tab: opts.tab, level: level})
+	})
+}
@@ -711,3 +711,272 @@ func TestTailscaleScraper_ScrapeArticle_MixedContent(t *testing.T) {
 		}
 	}
 }
+
+const tailscaleTabsHTML = `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<article id="main-content">
+		<h1>Install Tailscale</h1>
+		<div class="ts-prose">
+			<h2><a href="#install"><span id="inner-text">Install the client</span></a></h2>
+			<div dir="ltr" data-orientation="horizontal">
+				<div role="tablist" aria-orientation="horizontal">
+					<button type="button" role="tab" id="trigger-linux" aria-controls="content-linux">Linux</button>
+					<button type="button" role="tab" id="trigger-macos" aria-controls="content-macos">macOS</button>
+				</div>
+				<div role="tabpanel" id="content-linux" aria-labelledby="trigger-linux">
+					<p>Run the install script:</p>
+					<div class="group relative overflow-hidden">
+						<pre class="refractor language-shell"><code class="language-shell">curl -fsSL https://tailscale.com/install.sh | sh</code></pre>
+					</div>
+				</div>
+				<div role="tabpanel" id="content-macos" aria-labelledby="trigger-macos">
+					<p>Download the app from the App Store.</p>
+				</div>
+			</div>
+			<p>Then log in.</p>
+		</div>
+	</article>
+</body>
+</html>`
+
+func TestTailscaleScraper_ScrapeArticle_Tabs(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(tailscaleTabsHTML))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		tab      string
+		expected string
+	}{
+		{
+			name: "all tabs",
+			expected: "## Install the client\n\n" +
+				"### Linux\n\n" +
+				"Run the install script:\n\n" +
+				"```shell\ncurl -fsSL https://tailscale.com/install.sh | sh\n```\n\n" +
+				"### macOS\n\n" +
+				"Download the app from the App Store.\n\n" +
+				"Then log in.\n\n",
+		},
+		{
+			name: "selected tab",
+			tab:  "macos",
+			expected: "## Install the client\n\n" +
+				"Download the app from the App Store.\n\n" +
+				"Then log in.\n\n",
+		},
+		{
+			name: "unknown tab",
+			tab:  "Windows",
+			expected: "## Install the client\n\n" +
+				"### Linux\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper := &TailscaleScraper{Tab: tt.tab}
+			result, err := scraper.ScrapeArticle(server.URL)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(result, tt.expected) {
+				t.Errorf("expected %q in result, got: %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestTailscaleScraper_ScrapeArticle_Callouts(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<article id="main-content">
+		<h1>Title</h1>
+		<div class="ts-prose">
+			<div class="warning">
+				<p>Do not share your auth key.</p>
+				<p>Revoke it if leaked.</p>
+			</div>
+			<div class="callout callout-tip">
+				<p>Use <code>tailscale status</code> to check.</p>
+			</div>
+			<div class="note">
+				<p>Check the version:</p>
+				<div class="group relative overflow-hidden">
+					<pre class="refractor language-shell"><code class="language-shell">tailscale version</code></pre>
+				</div>
+			</div>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &TailscaleScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"> **Warning**\n>\n> Do not share your auth key.\n>\n> Revoke it if leaked.\n\n" +
+		"> **Tip**\n>\n> Use `tailscale status` to check.\n\n" +
+		"> **Note**\n>\n> Check the version:\n>\n> ```shell\n> tailscale version\n> ```\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestTailscaleScraper_ScrapeArticle_ImagesAndTables(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<article id="main-content">
+		<h1>Title</h1>
+		<div class="ts-prose">
+			<figure>
+				<img src="/kb/images/admin-console.png" alt="The admin console">
+				<figcaption>The Machines page</figcaption>
+			</figure>
+			<p>Click <img src="/kb/images/menu.svg" alt="menu"> to open the menu.</p>
+			<div class="overflow-x-auto">
+				<table>
+					<thead><tr><th>Flag</th><th>Description</th></tr></thead>
+					<tbody><tr><td><code>--ssh</code></td><td>Run an SSH server | on port 22</td></tr></tbody>
+				</table>
+			</div>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &TailscaleScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"![The admin console](" + server.URL + "/kb/images/admin-console.png)\n\n" +
+		"*The Machines page*\n\n" +
+		"Click ![menu](" + server.URL + "/kb/images/menu.svg) to open the menu.\n\n" +
+		"| Flag | Description |\n" +
+		"| --- | --- |\n" +
+		"| `--ssh` | Run an SSH server \\| on port 22 |\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestTailscaleScraper_ScrapeArticle_NestedLists(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<article id="main-content">
+		<h1>Title</h1>
+		<div class="ts-prose">
+			<ul>
+				<li>Linux
+					<ul>
+						<li>Debian</li>
+						<li>Fedora</li>
+					</ul>
+				</li>
+				<li>macOS</li>
+			</ul>
+			<ol>
+				<li>
+					<p>Open the <a href="/admin">admin console</a>.</p>
+					<p>You need to be an <strong>Admin</strong>.</p>
+				</li>
+				<li>
+					<p>Run the command:</p>
+					<div class="group relative overflow-hidden">
+						<pre class="refractor language-shell"><code class="language-shell">tailscale up
+
+tailscale status</code></pre>
+					</div>
+					<div class="note">
+						<p>Restart the client if it fails.</p>
+					</div>
+					<ol>
+						<li>Check the output.</li>
+						<li>Copy the IP address.</li>
+					</ol>
+				</li>
+			</ol>
+			<ol start="3">
+				<li>Continue here.</li>
+			</ol>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &TailscaleScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"* Linux\n\n" +
+		"  * Debian\n" +
+		"  * Fedora\n" +
+		"\n" +
+		"* macOS\n" +
+		"\n" +
+		"1. Open the [admin console](/admin).\n" +
+		"\n" +
+		"   You need to be an Admin.\n" +
+		"\n" +
+		"2. Run the command:\n" +
+		"\n" +
+		"   ```shell\n" +
+		"   tailscale up\n" +
+		"\n" +
+		"   tailscale status\n" +
+		"   ```\n" +
+		"\n" +
+		"   > **Note**\n" +
+		"   >\n" +
+		"   > Restart the client if it fails.\n" +
+		"\n" +
+		"   1. Check the output.\n" +
+		"   2. Copy the IP address.\n" +
+		"\n" +
+		"3. Continue here.\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
@@ -2,12 +2,104 @@ package scraper
 
 import (
 	"fmt"
+	"path"
 	"strings"
 
+	"github.com/PuerkitoBio/goquery"
 	"github.com/gocolly/colly"
+	"golang.org/x/net/html"
 )
 
+// TofuguRubyFormat is the format in which ruby annotations (furigana) are
+// rendered.
+type TofuguRubyFormat string
+
+const (
+	// TofuguRubyParentheses renders readings in parentheses after their base
+	// text, e.g. 漢字(かんじ).
+	TofuguRubyParentheses TofuguRubyFormat = "parentheses"
+	// TofuguRubyHTML keeps ruby annotations as HTML, e.g.
+	// <ruby>漢字<rt>かんじ</rt></ruby>.
+	TofuguRubyHTML TofuguRubyFormat = "html"
+	// TofuguRubyAnki renders readings in the furigana format of Anki, e.g.
+	// 漢字[かんじ].
+	TofuguRubyAnki TofuguRubyFormat = "anki"
+
+	// tofuguRubyBase drops readings and keeps the base text only.
+	tofuguRubyBase TofuguRubyFormat = "base"
+)
+
+// TofuguHighlightStyle is the style in which colour-coded text, such as the
+// grammar highlighted in example sentences, is rendered.
+type TofuguHighlightStyle string
+
+const (
+	TofuguHighlightBold   TofuguHighlightStyle = "bold"
+	TofuguHighlightItalic TofuguHighlightStyle = "italic"
+	// TofuguHighlightHTML keeps the colour as an HTML span, e.g.
+	// <span style="color: red">ものの</span>.
+	TofuguHighlightHTML TofuguHighlightStyle = "html"
+	// TofuguHighlightNone renders colour-coded text as plain text.
+	TofuguHighlightNone TofuguHighlightStyle = "none"
+)
+
+// tofuguColors are the names of colours recognised in the classes of
+// colour-coded text, e.g. <span class="text-red">.
+var tofuguColors = []string{
+	"red",
+	"orange",
+	"yellow",
+	"green",
+	"teal",
+	"blue",
+	"purple",
+	"pink",
+	"brown",
+	"gray",
+	"grey",
+}
+
 type TofuguScraper struct {
+	// Ruby is the format of ruby annotations. Readings are rendered in
+	// parentheses by default.
+	Ruby TofuguRubyFormat
+	// AudioDir is the directory to which the audio of example sentences is
+	// downloaded and linked from the markdown. Audio is not downloaded if it
+	// is empty.
+	AudioDir string
+	// Highlight is the style of colour-coded text. Text is rendered in bold
+	// by default.
+	Highlight TofuguHighlightStyle
+	// Highlights maps colours, e.g. red or #ff0000, to the style of text in
+	// the colour, overriding Highlight.
+	Highlights map[string]TofuguHighlightStyle
+	// TOCLinks links the items of tables of contents to the headings of the
+	// article.
+	TOCLinks bool
+}
+
+// tofuguOptions holds the options of rendering an article.
+type tofuguOptions struct {
+	ruby       TofuguRubyFormat
+	highlight  TofuguHighlightStyle
+	highlights map[string]TofuguHighlightStyle
+	tocLinks   bool
+	// audio downloads audio of example sentences, which is nil if audio is
+	// not downloaded
+	audio *tofuguAudioDownloader
+}
+
+func (g *TofuguScraper) options() *tofuguOptions {
+	opts := &tofuguOptions{
+		ruby:       g.Ruby,
+		highlight:  g.Highlight,
+		highlights: g.Highlights,
+		tocLinks:   g.TOCLinks,
+	}
+	if g.AudioDir != "" {
+		opts.audio = newTofuguAudioDownloader(g.AudioDir)
+	}
+	return opts
 }
 
 // ScrapeArticle scrapes the article content from the specified URL
@@ -17,9 +109,11 @@ func (g *TofuguScraper) ScrapeArticle(url string) (string, error) {
 
 	var markdown string
 
+	opts := g.options()
+
 	// title
 	c.OnHTML("h1.article-title", func(e *colly.HTMLElement) {
-		markdown += parseTofuguTitle(e)
+		markdown += parseTofuguTitle(e, g.Ruby)
 	})
 
 	// minor title
@@ -32,14 +126,14 @@ func (g *TofuguScraper) ScrapeArticle(url string) (string, error) {
 	// article body
 	c.OnHTML("article div.main", func(e *colly.HTMLElement) {
 		foundBody = true
-		markdown += parseTofuguArticle(e)
+		markdown += parseTofuguArticle(e, opts)
 	})
 
 	if !foundBody {
 		// try alternative selector
 		c.OnHTML("article div.article-content div.container", func(e *colly.HTMLElement) {
 			foundBody = true
-			markdown += parseTofuguArticle(e)
+			markdown += parseTofuguArticle(e, opts)
 		})
 	}
 
@@ -47,6 +141,9 @@ func (g *TofuguScraper) ScrapeArticle(url string) (string, error) {
 	if err != nil {
 		return "", err
 	}
+	if opts.audio != nil && opts.audio.err != nil {
+		return "", opts.audio.err
+	}
 
 	return markdown, nil
 }
@@ -132,74 +229,94 @@ func getBasenameFromURL(url string) string {
 	return lastPart
 }
 
-func parseTofuguTitle(e *colly.HTMLElement) string {
+func parseTofuguTitle(e *colly.HTMLElement, ruby TofuguRubyFormat) string {
 	builder := strings.Builder{}
 	builder.WriteString("# ")
-	builder.WriteString(trimSpacesAndLineBreaks(e.Text))
+	builder.WriteString(trimSpacesAndLineBreaks(tofuguText(e.DOM, ruby)))
 	builder.WriteString("\n\n")
 	return builder.String()
 }
 
-func parseTofuguArticle(e *colly.HTMLElement) string {
+func parseTofuguArticle(e *colly.HTMLElement, opts *tofuguOptions) string {
 	builder := strings.Builder{}
 
 	// builder.WriteString(e.ChildText("div.short-explanation"))
 	// builder.WriteString("\n\n")
 
+	headings := parseTofuguHeadings(e, opts.ruby)
+
 	// iterate each child element
 	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
 		if child.DOM.Parent().IsSelection(e.DOM) {
 			switch child.Name {
 			case "h2":
 				builder.WriteString("## ")
-				builder.WriteString(removeExtraSpaces(child.Text))
+				builder.WriteString(trimSpacesAndLineBreaks(removeExtraSpaces(parseTofuguInline(child, opts))))
 				builder.WriteString("\n\n")
 			case "h3":
 				builder.WriteString("### ")
-				builder.WriteString(removeExtraSpaces(child.Text))
+				builder.WriteString(trimSpacesAndLineBreaks(removeExtraSpaces(parseTofuguInline(child, opts))))
 				builder.WriteString("\n\n")
 			case "h4":
 				builder.WriteString("#### ")
-				builder.WriteString(removeExtraSpaces(child.Text))
+				builder.WriteString(trimSpacesAndLineBreaks(removeExtraSpaces(parseTofuguInline(child, opts))))
 				builder.WriteString("\n\n")
 			case "h5":
 				builder.WriteString("##### ")
-				builder.WriteString(removeExtraSpaces(child.Text))
+				builder.WriteString(trimSpacesAndLineBreaks(removeExtraSpaces(parseTofuguInline(child, opts))))
 				builder.WriteString("\n\n")
 			case "p":
-				builder.WriteString(removeExtraSpaces(child.Text))
+				text := trimSpacesAndLineBreaks(removeExtraSpaces(parseTofuguInline(child, opts)))
+				if text == "" {
+					break
+				}
+				builder.WriteString(text)
 				builder.WriteString("\n\n")
-		case "ul":
-			if child.DOM.HasClass("example-sentence") {
-				builder.WriteString(parseExampleList(child))
-				break
-			}
+			case "ul":
+				if child.DOM.HasClass("example-sentence") {
+					builder.WriteString(parseExampleList(child, opts))
+					break
+				}
+
+				var toc *tofuguHeadings
+				if isTofuguTableOfContents(child.DOM, headings) {
+					toc = headings
+				}
+				builder.WriteString(parseTofuguList(child, child.DOM, "", opts, toc))
+				builder.WriteString("\n")
 
-			// assume it is table of contents
-			builder.WriteString(parseTableOfContents(child))
+			case "div":
+				if child.DOM.HasClass("article-audio-sentence") {
+					builder.WriteString(parseAudioSentenceList(child, opts, opts.audio.download(tofuguAudioSource(child))))
+					break
+				}
+				// images are often wrapped in containers
+				child.ForEach("figure", func(_ int, figure *colly.HTMLElement) {
+					builder.WriteString(parseTofuguFigure(figure, opts))
+				})
 
-		case "div":
-			if child.DOM.HasClass("article-audio-sentence") {
-				builder.WriteString(parseAudioSentenceList(child))
-			}
+			case "figure":
+				builder.WriteString(parseTofuguFigure(child, opts))
 
-		case "dl":
-			if child.DOM.HasClass("highlight-right") || child.DOM.HasClass("highlight-left") {
-				builder.WriteString(parseTofuguDefinitionList(child))
-			}
+			case "img":
+				if image := renderMarkdownImage(child, child.DOM); image != "" {
+					builder.WriteString(image)
+					builder.WriteString("\n\n")
+				}
 
-		case "ol":
-				child.ForEach("li", func(index int, li *colly.HTMLElement) {
-					fmt.Fprintf(&builder, "%d. ", index+1)
-					builder.WriteString(trimSpacesAndLineBreaks(li.Text))
-					builder.WriteString("\n")
-				})
+			case "dl":
+				if child.DOM.HasClass("highlight-right") || child.DOM.HasClass("highlight-left") {
+					builder.WriteString(parseTofuguDefinitionList(child, opts))
+				}
+
+			case "ol":
+				builder.WriteString(parseTofuguList(child, child.DOM, "", opts, nil))
 				builder.WriteString("\n")
 			case "table":
-				builder.WriteString(parseTofuguTable(child))
+				builder.WriteString(parseTofuguTable(child, opts))
 			case "blockquote":
 				builder.WriteString("> ")
-				builder.WriteString(parseTofuguBlockquote(child.Text))
+				builder.WriteString(parseTofuguBlockquote(parseTofuguInline(child, opts)))
 				builder.WriteString("\n\n")
 			}
 		}
@@ -208,40 +325,125 @@ func parseTofuguArticle(e *colly.HTMLElement) string {
 	return builder.String()
 }
 
-func parseTableOfContents(e *colly.HTMLElement) string {
+// tofuguHeadings holds the anchors of the headings of an article, to which
+// the items of its table of contents link.
+type tofuguHeadings struct {
+	// slugs maps the normalised text of each heading to its anchor
+	slugs map[string]string
+	// ids maps the id attribute of each heading to its anchor
+	ids map[string]string
+}
+
+// parseTofuguHeadings returns the anchors generated for the headings of an
+// article body by markdown renderers.
+func parseTofuguHeadings(e *colly.HTMLElement, ruby TofuguRubyFormat) *tofuguHeadings {
+	// ruby annotations in HTML are rendered as their base text and reading,
+	// which is the text of the parentheses format without the parentheses
+	if ruby == TofuguRubyHTML {
+		ruby = TofuguRubyParentheses
+	}
+
+	headings := &tofuguHeadings{
+		slugs: make(map[string]string),
+		ids:   make(map[string]string),
+	}
+	seen := make(map[string]int)
+	e.DOM.Children().Filter("h2, h3, h4, h5").Each(func(_ int, h *goquery.Selection) {
+		slug := markdownHeadingSlug(strings.Join(strings.Fields(tofuguText(h, ruby)), " "), seen)
+		text := normalizeTofuguHeading(tofuguText(h, tofuguRubyBase))
+		if _, ok := headings.slugs[text]; !ok {
+			headings.slugs[text] = slug
+		}
+		if id := h.AttrOr("id", ""); id != "" {
+			headings.ids[id] = slug
+		}
+	})
+	return headings
+}
+
+// anchor returns the anchor of the heading which an item of a table of
+// contents refers to, either by a link to its id or by its text. It returns
+// an empty string if there is no such heading.
+func (h *tofuguHeadings) anchor(li *goquery.Selection, label string) string {
+	href := tofuguListItemContents(li).Find("a[href^='#']").AddBack().Filter("a[href^='#']").First().AttrOr("href", "")
+	if slug, ok := h.ids[strings.TrimPrefix(href, "#")]; ok && href != "" {
+		return slug
+	}
+	return h.slugs[normalizeTofuguHeading(label)]
+}
+
+func normalizeTofuguHeading(text string) string {
+	return strings.ToLower(strings.Join(strings.Fields(text), " "))
+}
+
+// isTofuguTableOfContents returns true if a list is a table of contents,
+// which is either marked as one by its class or lists the headings of the
+// article, by links or by text.
+func isTofuguTableOfContents(list *goquery.Selection, headings *tofuguHeadings) bool {
+	class := strings.ToLower(list.AttrOr("class", ""))
+	if strings.Contains(class, "toc") || strings.Contains(class, "table-of-contents") {
+		return true
+	}
+
+	items := list.ChildrenFiltered("li")
+	if items.Length() == 0 {
+		return false
+	}
+	for i := range items.Nodes {
+		li := items.Eq(i)
+		if headings.anchor(li, tofuguText(tofuguListItemContents(li), tofuguRubyBase)) == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// parseTofuguList renders a <ul> or <ol> element as a markdown list,
+// including nested lists at any depth. Items of a table of contents, for
+// which toc is not nil, are rendered as their labels, which link to the
+// headings if TOC links are enabled. Items of other lists are rendered with
+// their inline content.
+func parseTofuguList(e *colly.HTMLElement, list *goquery.Selection, indent string, opts *tofuguOptions, toc *tofuguHeadings) string {
 	builder := strings.Builder{}
-	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
-		if len(li.DOM.ParentsFiltered("ul").Nodes) > 1 {
-			// skip it is a child as it should have been processed in the code
-			// below already
-			return
+	ordered := goquery.NodeName(list) == "ol"
+	list.ChildrenFiltered("li").Each(func(index int, li *goquery.Selection) {
+		marker := "* "
+		if ordered {
+			marker = fmt.Sprintf("%d. ", index+1)
 		}
 
-		builder.WriteString(parseTofuguListItem(li, 0))
-		li.ForEach("ul li", func(_ int, subli *colly.HTMLElement) {
-			builder.WriteString(parseTofuguListItem(subli, 1))
-			subli.ForEach("ul li", func(_ int, subsubli *colly.HTMLElement) {
-				builder.WriteString(parseTofuguListItem(subsubli, 2))
-			})
+		contents := tofuguListItemContents(li)
+		var text string
+		if toc != nil {
+			text = strings.Join(strings.Fields(firstLine(strings.TrimSpace(tofuguText(contents, opts.ruby)))), " ")
+			label := tofuguText(contents, tofuguRubyBase)
+			if anchor := toc.anchor(li, label); opts.tocLinks && anchor != "" && text != "" {
+				text = fmt.Sprintf("[%s](#%s)", text, anchor)
+			}
+		} else {
+			text = strings.Join(strings.Fields(parseTofuguInlineNodes(e, contents, opts)), " ")
+		}
+
+		builder.WriteString(indent)
+		builder.WriteString(marker)
+		builder.WriteString(text)
+		builder.WriteString("\n")
+
+		// nested lists are indented to the content of the item
+		li.ChildrenFiltered("ul, ol").Each(func(_ int, nested *goquery.Selection) {
+			builder.WriteString(parseTofuguList(e, nested, indent+strings.Repeat(" ", len(marker)), opts, toc))
 		})
 	})
-	builder.WriteString("\n")
-
 	return builder.String()
 }
 
-func parseTofuguListItem(e *colly.HTMLElement, level int) string {
-	builder := strings.Builder{}
-	for range level {
-		builder.WriteString("  ")
-	}
-	builder.WriteString("* ")
-	builder.WriteString(firstLine(e.Text))
-	builder.WriteString("\n")
-	return builder.String()
+// tofuguListItemContents returns the contents of a list item except its
+// nested lists.
+func tofuguListItemContents(li *goquery.Selection) *goquery.Selection {
+	return li.Contents().Not("ul, ol")
 }
 
-func parseExampleList(e *colly.HTMLElement) string {
+func parseExampleList(e *colly.HTMLElement, opts *tofuguOptions) string {
 	builder := strings.Builder{}
 	builder.WriteString("Example\n\n")
 	e.ForEach("li", func(index int, li *colly.HTMLElement) {
@@ -251,7 +453,7 @@ func parseExampleList(e *colly.HTMLElement) string {
 		case 1:
 			builder.WriteString("- English:\n")
 		}
-		sentence := fmt.Sprintf("  * %s\n", trimSpacesAndLineBreaks(li.Text))
+		sentence := fmt.Sprintf("  * %s\n", trimSpacesAndLineBreaks(parseTofuguInline(li, opts)))
 		builder.WriteString(sentence)
 		builder.WriteString("\n")
 	})
@@ -260,7 +462,9 @@ func parseExampleList(e *colly.HTMLElement) string {
 	return builder.String()
 }
 
-func parseAudioSentenceList(e *colly.HTMLElement) string {
+// parseAudioSentenceList renders an example sentence with its translation
+// and, if audio is not empty, a link to its audio.
+func parseAudioSentenceList(e *colly.HTMLElement, opts *tofuguOptions, audio string) string {
 	builder := strings.Builder{}
 	builder.WriteString("Example\n\n")
 	index := 0
@@ -271,24 +475,28 @@ func parseAudioSentenceList(e *colly.HTMLElement) string {
 		case 1:
 			builder.WriteString("- English:\n")
 		}
-		sentence := fmt.Sprintf("  * %s\n", trimSpacesAndLineBreaks(li.Text))
+		sentence := fmt.Sprintf("  * %s\n", trimSpacesAndLineBreaks(parseTofuguInline(li, opts)))
 		builder.WriteString(sentence)
 		builder.WriteString("\n")
 		index++
 	})
+	if audio != "" {
+		builder.WriteString("- Audio:\n")
+		fmt.Fprintf(&builder, "  * [%s](%s)\n\n", path.Base(audio), audio)
+	}
 	builder.WriteString("\n")
 
 	return builder.String()
 }
 
-func parseTofuguDefinitionList(e *colly.HTMLElement) string {
+func parseTofuguDefinitionList(e *colly.HTMLElement, opts *tofuguOptions) string {
 	builder := strings.Builder{}
 	e.ForEach("dt", func(_ int, dt *colly.HTMLElement) {
 		dd := dt.DOM.Next()
 		if dd.Length() == 0 {
 			return
 		}
-		fmt.Fprintf(&builder, "**%s** — %s\n\n", trimSpacesAndLineBreaks(dt.Text), trimSpacesAndLineBreaks(dd.Text()))
+		fmt.Fprintf(&builder, "**%s** — %s\n\n", trimSpacesAndLineBreaks(parseTofuguInline(dt, opts)), trimSpacesAndLineBreaks(parseTofuguInlineNodes(dt, dd.Contents(), opts)))
 	})
 	return builder.String()
 }
@@ -298,12 +506,12 @@ func firstLine(s string) string {
 	return lines[0]
 }
 
-func parseTofuguTable(table *colly.HTMLElement) string {
+func parseTofuguTable(table *colly.HTMLElement, opts *tofuguOptions) string {
 	builder := strings.Builder{}
 	table.ForEach("tr", func(rowIndex int, tr *colly.HTMLElement) {
 		tr.ForEach("th", func(_ int, th *colly.HTMLElement) {
 			builder.WriteString("| ")
-			builder.WriteString(parseTofuguTableCell(th.Text))
+			builder.WriteString(parseTofuguTableCell(parseTofuguInline(th, opts)))
 			builder.WriteString(" ")
 		})
 		if builder.Len() > 0 && rowIndex == 0 {
@@ -327,7 +535,7 @@ func parseTofuguTable(table *colly.HTMLElement) string {
 		}
 		tr.ForEach("td", func(_ int, td *colly.HTMLElement) {
 			builder.WriteString("| ")
-			builder.WriteString(parseTofuguTableCell(td.Text))
+			builder.WriteString(parseTofuguTableCell(parseTofuguInline(td, opts)))
 			builder.WriteString(" ")
 		})
 		builder.WriteString("|\n")
@@ -352,3 +560,190 @@ func parseTofuguTableCell(text string) string {
 func parseTofuguBlockquote(text string) string {
 	return strings.ReplaceAll(trimSpacesAndLineBreaks(text), "\n", "\n> ")
 }
+
+// tofuguText returns the text of a selection like Text, rendering ruby
+// annotations in the given format instead of concatenating the base text
+// and the reading.
+func tofuguText(sel *goquery.Selection, ruby TofuguRubyFormat) string {
+	builder := strings.Builder{}
+	for _, node := range sel.Nodes {
+		writeTofuguText(&builder, node, ruby)
+	}
+	return builder.String()
+}
+
+func writeTofuguText(builder *strings.Builder, node *html.Node, ruby TofuguRubyFormat) {
+	switch node.Type {
+	case html.TextNode:
+		builder.WriteString(node.Data)
+		return
+	case html.ElementNode:
+		switch node.Data {
+		case "ruby":
+			writeTofuguRuby(builder, node, ruby)
+			return
+		case "rt", "rp":
+			return
+		}
+	}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		writeTofuguText(builder, child, ruby)
+	}
+}
+
+// writeTofuguRuby renders a <ruby> element. Each <rt> annotates the base
+// text preceding it, e.g. <ruby>漢<rt>かん</rt>字<rt>じ</rt></ruby>. <rp>
+// elements hold fallback parentheses and are ignored.
+func writeTofuguRuby(builder *strings.Builder, node *html.Node, ruby TofuguRubyFormat) {
+	base := strings.Builder{}
+	for child := node.FirstChild; child != nil; child = child.NextSibling {
+		if child.Type == html.ElementNode && child.Data == "rp" {
+			continue
+		}
+		if child.Type != html.ElementNode || child.Data != "rt" {
+			writeTofuguText(&base, child, ruby)
+			continue
+		}
+
+		reading := strings.TrimSpace(tofuguText(goquery.NewDocumentFromNode(child).Contents(), ruby))
+		text := strings.TrimSpace(base.String())
+		base.Reset()
+		if reading == "" {
+			builder.WriteString(text)
+			continue
+		}
+		switch ruby {
+		case tofuguRubyBase:
+			builder.WriteString(text)
+		case TofuguRubyHTML:
+			fmt.Fprintf(builder, "<ruby>%s<rt>%s</rt></ruby>", text, reading)
+		case TofuguRubyAnki:
+			// Anki takes the text after the last space as the base text
+			current := builder.String()
+			if current != "" && !strings.HasSuffix(current, " ") && !strings.HasSuffix(current, "\n") {
+				builder.WriteString(" ")
+			}
+			fmt.Fprintf(builder, "%s[%s]", text, reading)
+		default:
+			fmt.Fprintf(builder, "%s(%s)", text, reading)
+		}
+	}
+	builder.WriteString(base.String())
+}
+
+// parseTofuguInline renders the inline content of an element as markdown.
+func parseTofuguInline(e *colly.HTMLElement, opts *tofuguOptions) string {
+	return parseTofuguInlineNodes(e, e.DOM.Contents(), opts)
+}
+
+// parseTofuguInlineNodes renders the nodes of a selection as inline
+// markdown, preserving <a> as links with absolute URLs, <b>/<strong> as
+// bold, <i>/<em> as italic, colour-coded text in the style of its colour,
+// images and ruby annotations.
+func parseTofuguInlineNodes(e *colly.HTMLElement, sel *goquery.Selection, opts *tofuguOptions) string {
+	builder := strings.Builder{}
+	for i, node := range sel.Nodes {
+		switch node.Type {
+		case html.TextNode:
+			builder.WriteString(node.Data)
+		case html.ElementNode:
+			child := sel.Eq(i)
+			switch node.Data {
+			case "ruby":
+				writeTofuguRuby(&builder, node, opts.ruby)
+			case "rt", "rp", "audio", "button", "script", "style":
+			case "a":
+				href, _ := child.Attr("href")
+				text := strings.TrimSpace(parseTofuguInlineNodes(e, child.Contents(), opts))
+				if href != "" && text != "" {
+					fmt.Fprintf(&builder, "[%s](%s)", text, e.Request.AbsoluteURL(href))
+				} else {
+					builder.WriteString(text)
+				}
+			case "b", "strong":
+				builder.WriteString(wrapTofuguText(parseTofuguInlineNodes(e, child.Contents(), opts), "**", "**"))
+			case "i", "em":
+				builder.WriteString(wrapTofuguText(parseTofuguInlineNodes(e, child.Contents(), opts), "*", "*"))
+			case "img":
+				builder.WriteString(renderMarkdownImage(e, child))
+			case "br":
+				builder.WriteString(" ")
+			default:
+				text := parseTofuguInlineNodes(e, child.Contents(), opts)
+				if color := tofuguHighlightColor(child); color != "" {
+					text = parseTofuguHighlight(text, color, opts)
+				}
+				builder.WriteString(text)
+			}
+		}
+	}
+	return builder.String()
+}
+
+// parseTofuguHighlight renders colour-coded text in the style configured
+// for its colour.
+func parseTofuguHighlight(text, color string, opts *tofuguOptions) string {
+	style, ok := opts.highlights[color]
+	if !ok {
+		style = opts.highlight
+	}
+	switch style {
+	case TofuguHighlightNone:
+		return text
+	case TofuguHighlightItalic:
+		return wrapTofuguText(text, "*", "*")
+	case TofuguHighlightHTML:
+		return wrapTofuguText(text, fmt.Sprintf("<span style=\"color: %s\">", color), "</span>")
+	default:
+		return wrapTofuguText(text, "**", "**")
+	}
+}
+
+// tofuguHighlightColor returns the colour of colour-coded text, from either
+// the color property of its style or a class naming a colour such as
+// text-red. It returns an empty string if the text is not colour-coded.
+func tofuguHighlightColor(sel *goquery.Selection) string {
+	style, _ := sel.Attr("style")
+	for _, declaration := range strings.Split(style, ";") {
+		property, value, found := strings.Cut(declaration, ":")
+		if found && strings.EqualFold(strings.TrimSpace(property), "color") {
+			return strings.ToLower(strings.TrimSpace(value))
+		}
+	}
+
+	class, _ := sel.Attr("class")
+	for _, name := range strings.Fields(strings.ToLower(class)) {
+		for _, part := range strings.Split(name, "-") {
+			for _, color := range tofuguColors {
+				if part == color {
+					return color
+				}
+			}
+		}
+	}
+	return ""
+}
+
+// wrapTofuguText wraps text in markup such as ** while keeping its
+// surrounding spaces outside the markup. Text which is already wrapped in
+// the markup is returned as is, e.g. bold text highlighted in bold.
+func wrapTofuguText(text, open, close string) string {
+	trimmed := strings.TrimSpace(text)
+	if trimmed == "" {
+		return text
+	}
+	if strings.HasPrefix(trimmed, open) && strings.HasSuffix(trimmed, close) {
+		return text
+	}
+	leading := text[:strings.Index(text, trimmed)]
+	trailing := text[len(leading)+len(trimmed):]
+	return leading + open + trimmed + close + trailing
+}
+
+// parseTofuguFigure renders a <figure> element as a markdown image followed
+// by its caption in italics.
+func parseTofuguFigure(e *colly.HTMLElement, opts *tofuguOptions) string {
+	return renderMarkdownFigure(e, func(caption *colly.HTMLElement) string {
+		return parseTofuguInlineNodes(caption, caption.DOM.Contents(), opts)
+	})
+}
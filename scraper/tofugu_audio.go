@@ -0,0 +1,109 @@
+package scraper
+
+import (
+	"fmt"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// tofuguAudioDownloader downloads the audio of example sentences of an
+// article to a directory. Each URL is downloaded once and files which
+// already exist are kept, so that scraping an article again does not
+// download its audio again.
+type tofuguAudioDownloader struct {
+	dir string
+	// files maps the URL of each audio to its link
+	files map[string]string
+	// err is the first error of downloading
+	err error
+}
+
+func newTofuguAudioDownloader(dir string) *tofuguAudioDownloader {
+	return &tofuguAudioDownloader{
+		dir:   dir,
+		files: make(map[string]string),
+	}
+}
+
+// download downloads the audio of the specified URL and returns the link to
+// the downloaded file, which is relative to the current directory if the
+// directory is. It returns an empty string if the audio is not downloaded.
+func (d *tofuguAudioDownloader) download(audioURL string) string {
+	if d == nil || audioURL == "" || d.err != nil {
+		return ""
+	}
+	if link, ok := d.files[audioURL]; ok {
+		return link
+	}
+
+	filename := tofuguAudioFilename(audioURL)
+	if filename == "" {
+		return ""
+	}
+	filePath := filepath.Join(d.dir, filename)
+	link := path.Join(filepath.ToSlash(d.dir), filename)
+
+	if _, err := os.Stat(filePath); err == nil {
+		d.files[audioURL] = link
+		return link
+	}
+	if err := os.MkdirAll(d.dir, 0o755); err != nil {
+		d.err = fmt.Errorf("unable to create directory of audio: %w", err)
+		return ""
+	}
+
+	collector := colly.NewCollector()
+	var saveErr error
+	collector.OnResponse(func(r *colly.Response) {
+		saveErr = r.Save(filePath)
+	})
+	if err := collector.Visit(audioURL); err != nil {
+		d.err = fmt.Errorf("unable to download audio %s: %w", audioURL, err)
+		return ""
+	}
+	if saveErr != nil {
+		d.err = fmt.Errorf("unable to save audio %s: %w", audioURL, saveErr)
+		return ""
+	}
+
+	d.files[audioURL] = link
+	return link
+}
+
+// tofuguAudioSource returns the URL of the audio of an example sentence,
+// preferring MP3 which is supported by Anki on every platform.
+func tofuguAudioSource(e *colly.HTMLElement) string {
+	sources := e.DOM.Find("audio source[src]")
+	source := sources.FilterFunction(func(_ int, s *goquery.Selection) bool {
+		return s.AttrOr("type", "") == "audio/mpeg"
+	}).First()
+	if source.Length() == 0 {
+		source = sources.First()
+	}
+	if source.Length() == 0 {
+		return ""
+	}
+	return e.Request.AbsoluteURL(source.AttrOr("src", ""))
+}
+
+// tofuguAudioFilename returns a filename of an audio URL which is stable
+// and unique across articles. Tofugu names the audio of each article 01.mp3,
+// 02.mp3 and so on, so the directory of the article is kept as a prefix, e.g.
+// 2017-03-21-japanese-particle-monono-01.mp3.
+func tofuguAudioFilename(audioURL string) string {
+	parsed, err := url.Parse(audioURL)
+	if err != nil {
+		return removeNonFilenameChars(path.Base(audioURL))
+	}
+	base := path.Base(parsed.Path)
+	dir := path.Base(path.Dir(parsed.Path))
+	if dir == "/" || dir == "." {
+		return removeNonFilenameChars(base)
+	}
+	return removeNonFilenameChars(dir + "-" + base)
+}
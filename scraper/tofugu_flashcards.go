@@ -0,0 +1,200 @@
+package scraper
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// tofuguFlashcardSelector selects the example sentences and the definitions
+// of an article body which are exported as flashcards.
+const tofuguFlashcardSelector = "ul.example-sentence, div.article-audio-sentence, dl.highlight-right, dl.highlight-left"
+
+// TofuguFlashcardDeck is the flashcards of the example sentences and the
+// definitions of a Tofugu article.
+type TofuguFlashcardDeck struct {
+	Title string
+	Cards []TofuguFlashcard
+}
+
+// TofuguFlashcard is a Japanese sentence or term with its English
+// translation or definition.
+type TofuguFlashcard struct {
+	// Japanese is the sentence or term without readings.
+	Japanese string
+	// Reading is the sentence or term with readings in the furigana format
+	// of Anki, which is empty if it has no ruby annotations.
+	Reading string
+	English string
+	// Source is the URL of the article.
+	Source string
+	Tags   []string
+	// Audio is the filename of the pronunciation of the sentence and
+	// AudioURL is where it is downloaded from.
+	Audio    string
+	AudioURL string
+}
+
+// ScrapeFlashcards scrapes the example sentences and the definitions of the
+// article of the specified URL as flashcards. If AudioDir is set, the audio
+// of the example sentences is downloaded to it.
+func (g *TofuguScraper) ScrapeFlashcards(url string) (*TofuguFlashcardDeck, error) {
+	c := colly.NewCollector()
+
+	deck := &TofuguFlashcardDeck{}
+	foundBody := false
+
+	c.OnHTML("h1.article-title", func(e *colly.HTMLElement) {
+		deck.Title = trimSpacesAndLineBreaks(tofuguText(e.DOM, tofuguRubyBase))
+	})
+
+	c.OnHTML("article div.main, article div.article-content div.container", func(e *colly.HTMLElement) {
+		if foundBody {
+			return
+		}
+		foundBody = true
+		e.ForEach(tofuguFlashcardSelector, func(_ int, child *colly.HTMLElement) {
+			deck.Cards = append(deck.Cards, parseTofuguFlashcards(child)...)
+		})
+	})
+
+	err := c.Visit(url)
+	if err != nil {
+		return nil, err
+	}
+
+	tags := tofuguFlashcardTags(deck.Title)
+	for i := range deck.Cards {
+		deck.Cards[i].Source = url
+		deck.Cards[i].Tags = tags
+	}
+
+	// the audio is saved under the filenames the cards refer to
+	if g.AudioDir != "" {
+		audio := newTofuguAudioDownloader(g.AudioDir)
+		for _, card := range deck.Cards {
+			audio.download(card.AudioURL)
+		}
+		if audio.err != nil {
+			return nil, audio.err
+		}
+	}
+
+	return deck, nil
+}
+
+// parseTofuguFlashcards returns the flashcards of an example sentence list
+// or a definition list.
+func parseTofuguFlashcards(e *colly.HTMLElement) []TofuguFlashcard {
+	switch e.Name {
+	case "ul":
+		card := newTofuguFlashcard(e.DOM.Find("li").Eq(0), e.DOM.Find("li").Eq(1))
+		if card.Japanese == "" {
+			return nil
+		}
+		return []TofuguFlashcard{card}
+
+	case "div":
+		sentences := e.DOM.Find("li.article-audio-sentence-sentence")
+		card := newTofuguFlashcard(sentences.Eq(0), sentences.Eq(1))
+		if card.Japanese == "" {
+			return nil
+		}
+		card.AudioURL = tofuguAudioSource(e)
+		if card.AudioURL != "" {
+			card.Audio = tofuguAudioFilename(card.AudioURL)
+		}
+		return []TofuguFlashcard{card}
+
+	case "dl":
+		var cards []TofuguFlashcard
+		e.ForEach("dt", func(_ int, dt *colly.HTMLElement) {
+			dd := dt.DOM.Next()
+			if dd.Length() == 0 {
+				return
+			}
+			card := newTofuguFlashcard(dt.DOM, dd)
+			if card.Japanese == "" {
+				return
+			}
+			cards = append(cards, card)
+		})
+		return cards
+	}
+	return nil
+}
+
+// newTofuguFlashcard returns a flashcard of a Japanese sentence or term and
+// its English translation or definition.
+func newTofuguFlashcard(japanese, english *goquery.Selection) TofuguFlashcard {
+	card := TofuguFlashcard{
+		Japanese: trimSpacesAndLineBreaks(removeExtraSpaces(tofuguText(japanese, tofuguRubyBase))),
+		English:  trimSpacesAndLineBreaks(removeExtraSpaces(tofuguText(english, tofuguRubyBase))),
+	}
+	reading := trimSpacesAndLineBreaks(removeExtraSpaces(tofuguText(japanese, TofuguRubyAnki)))
+	if reading != card.Japanese {
+		card.Reading = reading
+	}
+	return card
+}
+
+// tofuguFlashcardTags returns the tags of the flashcards of an article. Tags
+// of Anki cannot contain spaces.
+func tofuguFlashcardTags(title string) []string {
+	tags := []string{"tofugu"}
+	title = strings.ReplaceAll(title, "〜", "")
+	if tag := strings.Join(strings.Fields(title), "_"); tag != "" {
+		tags = append(tags, tag)
+	}
+	return tags
+}
+
+// Write writes the flashcards as a delimited text file which can be imported
+// into Anki, with one card per line and the fields Japanese, Reading,
+// English, Source, Tags and, if audio is set, Audio. comma is the field
+// delimiter, e.g. '\t' for TSV or ',' for CSV. The file headers of Anki
+// describe the separator, the columns and the deck named after the article.
+func (d *TofuguFlashcardDeck) Write(w io.Writer, comma rune, audio bool) error {
+	separator := "comma"
+	if comma == '\t' {
+		separator = "tab"
+	}
+	columns := []string{"Japanese", "Reading", "English", "Source", "Tags"}
+	if audio {
+		columns = append(columns, "Audio")
+	}
+
+	header := strings.Builder{}
+	fmt.Fprintf(&header, "#separator:%s\n", separator)
+	header.WriteString("#html:false\n")
+	if d.Title != "" {
+		fmt.Fprintf(&header, "#deck:%s\n", d.Title)
+	}
+	fmt.Fprintf(&header, "#columns:%s\n", strings.Join(columns, string(comma)))
+	header.WriteString("#tags column:5\n")
+	if _, err := io.WriteString(w, header.String()); err != nil {
+		return err
+	}
+
+	writer := csv.NewWriter(w)
+	writer.Comma = comma
+	for _, card := range d.Cards {
+		record := []string{card.Japanese, card.Reading, card.English, card.Source, strings.Join(card.Tags, " ")}
+		if audio {
+			sound := ""
+			if card.Audio != "" {
+				sound = fmt.Sprintf("[sound:%s]", card.Audio)
+			}
+			record = append(record, sound)
+		}
+		if err := writer.Write(record); err != nil {
+			return err
+		}
+	}
+	writer.Flush()
+	return writer.Error()
+}
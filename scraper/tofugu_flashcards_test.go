@@ -0,0 +1,188 @@
+package scraper
+
+import (
+	"bytes"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const tofuguFlashcardsHTML = `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1 class="article-title">〜ものの (Monono)</h1>
+	<article>
+		<div class="main">
+			<p>Introduction</p>
+			<dl class="highlight-right">
+				<dt>ものの</dt>
+				<dd>but; although</dd>
+			</dl>
+			<ul class="example-sentence">
+				<li><ruby>日本<rt>にほん</rt></ruby>に<ruby>行<rt>い</rt></ruby>ったものの、何もしなかった。</li>
+				<li>I went to Japan, but I didn't do anything.</li>
+			</ul>
+			<div class="article-audio-sentence"><ul>
+				<li class="article-audio-sentence-player"><button type="button" title="Click to play audio"></button><audio preload="none"><source src="/articles/japanese/2017-03-21-japanese-particle-monono/01.ogg" type="audio/ogg" /><source src="/articles/japanese/2017-03-21-japanese-particle-monono/01.mp3" type="audio/mpeg" /></audio></li>
+				<li class="article-audio-sentence-sentence">買ったものの、使っていない。</li>
+				<li class="article-audio-sentence-sentence">I bought it, but I haven't used it.</li>
+			</ul></div>
+		</div>
+	</article>
+</body>
+</html>`
+
+func TestTofuguScraper_ScrapeFlashcards(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(tofuguFlashcardsHTML))
+	}))
+	defer server.Close()
+
+	scraper := &TofuguScraper{}
+	deck, err := scraper.ScrapeFlashcards(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if deck.Title != "〜ものの (Monono)" {
+		t.Errorf("expected title of the article, got %q", deck.Title)
+	}
+	if len(deck.Cards) != 3 {
+		t.Fatalf("expected 3 cards, got %d: %+v", len(deck.Cards), deck.Cards)
+	}
+
+	definition := deck.Cards[0]
+	if definition.Japanese != "ものの" || definition.English != "but; although" || definition.Reading != "" {
+		t.Errorf("unexpected definition card: %+v", definition)
+	}
+
+	example := deck.Cards[1]
+	if example.Japanese != "日本に行ったものの、何もしなかった。" {
+		t.Errorf("expected Japanese without readings, got %q", example.Japanese)
+	}
+	if example.Reading != "日本[にほん]に 行[い]ったものの、何もしなかった。" {
+		t.Errorf("expected reading in Anki format, got %q", example.Reading)
+	}
+	if example.English != "I went to Japan, but I didn't do anything." {
+		t.Errorf("unexpected English: %q", example.English)
+	}
+	if example.Source != server.URL {
+		t.Errorf("expected source URL, got %q", example.Source)
+	}
+	if strings.Join(example.Tags, " ") != "tofugu ものの_(Monono)" {
+		t.Errorf("unexpected tags: %v", example.Tags)
+	}
+	if example.Audio != "" {
+		t.Errorf("expected no audio for example without player, got %q", example.Audio)
+	}
+
+	audio := deck.Cards[2]
+	if audio.Japanese != "買ったものの、使っていない。" {
+		t.Errorf("unexpected Japanese: %q", audio.Japanese)
+	}
+	if audio.AudioURL != server.URL+"/articles/japanese/2017-03-21-japanese-particle-monono/01.mp3" {
+		t.Errorf("expected absolute URL of the mp3 audio, got %q", audio.AudioURL)
+	}
+	if audio.Audio != "2017-03-21-japanese-particle-monono-01.mp3" {
+		t.Errorf("unexpected audio filename: %q", audio.Audio)
+	}
+}
+
+func TestTofuguScraper_ScrapeFlashcards_AudioDir(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".mp3") {
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte("mp3 " + r.URL.Path))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(tofuguFlashcardsHTML))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "audio")
+	scraper := &TofuguScraper{AudioDir: dir}
+	deck, err := scraper.ScrapeFlashcards(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	content, err := os.ReadFile(filepath.Join(dir, deck.Cards[2].Audio))
+	if err != nil {
+		t.Fatalf("expected audio file referred to by the card to be downloaded: %v", err)
+	}
+	if string(content) != "mp3 /articles/japanese/2017-03-21-japanese-particle-monono/01.mp3" {
+		t.Errorf("unexpected content of audio file: %q", content)
+	}
+}
+
+func TestTofuguFlashcardDeck_Write(t *testing.T) {
+	deck := &TofuguFlashcardDeck{
+		Title: "〜ものの (Monono)",
+		Cards: []TofuguFlashcard{
+			{
+				Japanese: "ものの",
+				English:  "but; although",
+				Source:   "https://www.tofugu.com/japanese-grammar/monono/",
+				Tags:     []string{"tofugu", "ものの_(Monono)"},
+			},
+			{
+				Japanese: "買ったものの、使っていない。",
+				English:  "I bought it, but I haven't used it.",
+				Source:   "https://www.tofugu.com/japanese-grammar/monono/",
+				Tags:     []string{"tofugu", "ものの_(Monono)"},
+				Audio:    "monono-01.mp3",
+			},
+		},
+	}
+
+	tests := []struct {
+		name     string
+		comma    rune
+		audio    bool
+		expected string
+	}{
+		{
+			name:  "tsv",
+			comma: '\t',
+			expected: "#separator:tab\n" +
+				"#html:false\n" +
+				"#deck:〜ものの (Monono)\n" +
+				"#columns:Japanese\tReading\tEnglish\tSource\tTags\n" +
+				"#tags column:5\n" +
+				"ものの\t\tbut; although\thttps://www.tofugu.com/japanese-grammar/monono/\ttofugu ものの_(Monono)\n" +
+				"買ったものの、使っていない。\t\tI bought it, but I haven't used it.\thttps://www.tofugu.com/japanese-grammar/monono/\ttofugu ものの_(Monono)\n",
+		},
+		{
+			name:  "csv with audio",
+			comma: ',',
+			audio: true,
+			expected: "#separator:comma\n" +
+				"#html:false\n" +
+				"#deck:〜ものの (Monono)\n" +
+				"#columns:Japanese,Reading,English,Source,Tags,Audio\n" +
+				"#tags column:5\n" +
+				"ものの,,but; although,https://www.tofugu.com/japanese-grammar/monono/,tofugu ものの_(Monono),\n" +
+				"買ったものの、使っていない。,,\"I bought it, but I haven't used it.\",https://www.tofugu.com/japanese-grammar/monono/,tofugu ものの_(Monono),[sound:monono-01.mp3]\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buffer bytes.Buffer
+			if err := deck.Write(&buffer, tt.comma, tt.audio); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if buffer.String() != tt.expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", tt.expected, buffer.String())
+			}
+		})
+	}
+}
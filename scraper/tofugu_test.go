@@ -3,6 +3,8 @@ package scraper
 import (
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"testing"
 )
@@ -2387,3 +2389,396 @@ func TestTofuguScraper_ScrapeFilename_WithQuestionAndAmpersand(t *testing.T) {
 		t.Errorf("ScrapeFilename() = %q, want %q", result, expected)
 	}
 }
+
+func TestTofuguScraper_ScrapeArticle_Ruby(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1 class="article-title">Title</h1>
+	<article>
+		<div class="main">
+			<p>この<ruby>漢字<rp>(</rp><rt>かんじ</rt><rp>)</rp></ruby>は<ruby>難<rt>むずか</rt></ruby>しい。</p>
+			<div class="article-audio-sentence"><ul>
+				<li class="article-audio-sentence-sentence"><ruby>日<rt>に</rt>本<rt>ほん</rt></ruby>へ<ruby>行<rt>い</rt></ruby>く</li>
+				<li class="article-audio-sentence-sentence">I go to Japan</li>
+			</ul></div>
+			<dl class="highlight-right">
+				<dt><ruby>物<rt>もの</rt></ruby>の</dt>
+				<dd>but; although</dd>
+			</dl>
+			<table>
+				<tr><th>Word</th><th>Meaning</th></tr>
+				<tr><td><ruby>今日<rt>きょう</rt></ruby></td><td>today</td></tr>
+			</table>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		ruby     TofuguRubyFormat
+		expected []string
+	}{
+		{
+			ruby: "",
+			expected: []string{
+				"この漢字(かんじ)は難(むずか)しい。\n\n",
+				"  * 日(に)本(ほん)へ行(い)く\n",
+				"**物(もの)の** — but; although",
+				"| 今日(きょう) | today |",
+			},
+		},
+		{
+			ruby: TofuguRubyHTML,
+			expected: []string{
+				"この<ruby>漢字<rt>かんじ</rt></ruby>は<ruby>難<rt>むずか</rt></ruby>しい。\n\n",
+				"  * <ruby>日<rt>に</rt></ruby><ruby>本<rt>ほん</rt></ruby>へ<ruby>行<rt>い</rt></ruby>く\n",
+				"**<ruby>物<rt>もの</rt></ruby>の** — but; although",
+				"| <ruby>今日<rt>きょう</rt></ruby> | today |",
+			},
+		},
+		{
+			ruby: TofuguRubyAnki,
+			expected: []string{
+				"この 漢字[かんじ]は 難[むずか]しい。\n\n",
+				"  * 日[に] 本[ほん]へ 行[い]く\n",
+				"**物[もの]の** — but; although",
+				"| 今日[きょう] | today |",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(string(tt.ruby), func(t *testing.T) {
+			scraper := &TofuguScraper{Ruby: tt.ruby}
+			result, err := scraper.ScrapeArticle(server.URL)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("expected %q in result, got: %q", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestTofuguScraper_ScrapeArticle_AudioDownload(t *testing.T) {
+	audioRequests := 0
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasSuffix(r.URL.Path, ".mp3") {
+			audioRequests++
+			w.Header().Set("Content-Type", "audio/mpeg")
+			w.Write([]byte("mp3 " + r.URL.Path))
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(`<!DOCTYPE html>
+<html>
+<body>
+	<h1 class="article-title">Title</h1>
+	<article>
+		<div class="main">
+			<div class="article-audio-sentence"><ul>
+				<li class="article-audio-sentence-player"><audio preload="none"><source src="/articles/monono/01.ogg" type="audio/ogg" /><source src="/articles/monono/01.mp3" type="audio/mpeg" /></audio></li>
+				<li class="article-audio-sentence-sentence">日本語の文</li>
+				<li class="article-audio-sentence-sentence">English translation</li>
+			</ul></div>
+			<div class="article-audio-sentence"><ul>
+				<li class="article-audio-sentence-player"><audio preload="none"><source src="` + server.URL + `/articles/monono/01.mp3" type="audio/mpeg" /></audio></li>
+				<li class="article-audio-sentence-sentence">日本語の文</li>
+				<li class="article-audio-sentence-sentence">English translation</li>
+			</ul></div>
+		</div>
+	</article>
+</body>
+</html>`))
+	}))
+	defer server.Close()
+
+	dir := filepath.Join(t.TempDir(), "audio")
+	scraper := &TofuguScraper{AudioDir: dir}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	link := filepath.ToSlash(filepath.Join(dir, "monono-01.mp3"))
+	expected := "- Audio:\n  * [monono-01.mp3](" + link + ")\n\n"
+	if strings.Count(result, expected) != 2 {
+		t.Errorf("expected a link to the audio from both sentences, got: %q", result)
+	}
+	if audioRequests != 1 {
+		t.Errorf("expected audio to be downloaded once, got %d requests", audioRequests)
+	}
+	content, err := os.ReadFile(filepath.Join(dir, "monono-01.mp3"))
+	if err != nil {
+		t.Fatalf("expected audio file to be downloaded: %v", err)
+	}
+	if string(content) != "mp3 /articles/monono/01.mp3" {
+		t.Errorf("unexpected content of audio file: %q", content)
+	}
+
+	// files which exist already are not downloaded again
+	if _, err := scraper.ScrapeArticle(server.URL); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if audioRequests != 1 {
+		t.Errorf("expected existing audio not to be downloaded again, got %d requests", audioRequests)
+	}
+}
+
+func TestTofuguAudioFilename(t *testing.T) {
+	tests := []struct {
+		url      string
+		expected string
+	}{
+		{"https://files.tofugu.com/articles/japanese/2017-03-21-japanese-particle-monono/01.mp3", "2017-03-21-japanese-particle-monono-01.mp3"},
+		{"https://files.tofugu.com/01.mp3", "01.mp3"},
+		{"https://files.tofugu.com/articles/monono/01.mp3?version=2", "monono-01.mp3"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			result := tofuguAudioFilename(tt.url)
+			if result != tt.expected {
+				t.Errorf("tofuguAudioFilename(%q) = %q, want %q", tt.url, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestTofuguScraper_ScrapeArticle_InlineContent(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1 class="article-title">Title</h1>
+	<article>
+		<div class="main">
+			<h2>Using <em>ものの</em></h2>
+			<p>Use <strong>ものの</strong> like <a href="/japanese-grammar/kedo/">けど</a>, which is <i>casual</i>.</p>
+			<p>The clause before <span class="text-red">ものの</span> is <span style="font-weight: bold; color: #0000FF">true</span>.</p>
+			<ol>
+				<li>Conjugate the <b>verb</b></li>
+			</ol>
+			<table>
+				<tr><th>Form</th></tr>
+				<tr><td><span class="text-red">ものの</span></td></tr>
+			</table>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name       string
+		highlight  TofuguHighlightStyle
+		highlights map[string]TofuguHighlightStyle
+		expected   []string
+	}{
+		{
+			name: "default",
+			expected: []string{
+				"## Using *ものの*\n\n",
+				"Use **ものの** like [けど](" + server.URL + "/japanese-grammar/kedo/), which is *casual*.\n\n",
+				"The clause before **ものの** is **true**.\n\n",
+				"1. Conjugate the **verb**\n",
+				"| **ものの** |",
+			},
+		},
+		{
+			name:      "italic",
+			highlight: TofuguHighlightItalic,
+			expected: []string{
+				"The clause before *ものの* is *true*.\n\n",
+			},
+		},
+		{
+			name:      "none",
+			highlight: TofuguHighlightNone,
+			expected: []string{
+				"The clause before ものの is true.\n\n",
+			},
+		},
+		{
+			name:       "per colour",
+			highlights: map[string]TofuguHighlightStyle{"red": TofuguHighlightHTML, "#0000ff": TofuguHighlightNone},
+			expected: []string{
+				"The clause before <span style=\"color: red\">ものの</span> is true.\n\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper := &TofuguScraper{Highlight: tt.highlight, Highlights: tt.highlights}
+			result, err := scraper.ScrapeArticle(server.URL)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(result, expected) {
+					t.Errorf("expected %q in result, got: %q", expected, result)
+				}
+			}
+		})
+	}
+}
+
+func TestTofuguScraper_ScrapeArticle_Images(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1 class="article-title">Title</h1>
+	<article>
+		<div class="main">
+			<figure>
+				<img src="/images/monono.jpg" alt="A cat   looking at food">
+				<figcaption>The cat wants it, but <a href="/japanese-grammar/kedo/">can't</a> reach it.</figcaption>
+			</figure>
+			<div class="image-wrapper">
+				<figure><img src="data:image/gif;base64,R0lGOD" data-src="https://files.tofugu.com/lazy.png" alt="Lazy"></figure>
+			</div>
+			<p><img src="/images/inline.png" alt="Inline"></p>
+			<figure><figcaption>No image</figcaption></figure>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &TofuguScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Title\n\n" +
+		"![A cat looking at food](" + server.URL + "/images/monono.jpg)\n\n" +
+		"*The cat wants it, but [can't](" + server.URL + "/japanese-grammar/kedo/) reach it.*\n\n" +
+		"![Lazy](https://files.tofugu.com/lazy.png)\n\n" +
+		"![Inline](" + server.URL + "/images/inline.png)\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestTofuguScraper_ScrapeArticle_Lists(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<head><title>Test</title></head>
+<body>
+	<h1 class="article-title">Title</h1>
+	<article>
+		<div class="main">
+			<ul>
+				<li>What is ものの?</li>
+				<li><a href="#how-to">How to Use</a>
+					<ul>
+						<li>Verb + ものの
+							<ul>
+								<li>Past Tense</li>
+							</ul>
+						</li>
+					</ul>
+				</li>
+			</ul>
+			<h2>What is ものの?</h2>
+			<p>Introduction</p>
+			<ul>
+				<li>It is <strong>formal</strong>, see <a href="/japanese-grammar/kedo/">けど</a>
+					<ol>
+						<li>Step one
+							<ul>
+								<li>Detail</li>
+							</ul>
+						</li>
+					</ol>
+				</li>
+				<li>It is written</li>
+			</ul>
+			<h2 id="how-to">How to Use <ruby>物<rt>もの</rt></ruby>の</h2>
+			<p>Usage</p>
+			<h3>Verb + ものの</h3>
+			<h4>Past Tense</h4>
+		</div>
+	</article>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	list := "* It is **formal**, see [けど](" + server.URL + "/japanese-grammar/kedo/)\n" +
+		"  1. Step one\n" +
+		"     * Detail\n" +
+		"* It is written\n\n"
+
+	tests := []struct {
+		name     string
+		tocLinks bool
+		toc      string
+	}{
+		{
+			name: "plain",
+			toc: "* What is ものの?\n" +
+				"* How to Use\n" +
+				"  * Verb + ものの\n" +
+				"    * Past Tense\n\n",
+		},
+		{
+			name:     "links",
+			tocLinks: true,
+			toc: "* [What is ものの?](#what-is-ものの)\n" +
+				"* [How to Use](#how-to-use-物ものの)\n" +
+				"  * [Verb + ものの](#verb--ものの)\n" +
+				"    * [Past Tense](#past-tense)\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scraper := &TofuguScraper{TOCLinks: tt.tocLinks}
+			result, err := scraper.ScrapeArticle(server.URL)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !strings.Contains(result, "# Title\n\n"+tt.toc) {
+				t.Errorf("expected table of contents %q, got: %q", tt.toc, result)
+			}
+			if !strings.Contains(result, "Introduction\n\n"+list) {
+				t.Errorf("expected list %q, got: %q", list, result)
+			}
+		})
+	}
+}
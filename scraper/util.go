@@ -2,7 +2,13 @@
 package scraper
 
 import (
+	"fmt"
 	"strings"
+	"unicode"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+	"golang.org/x/net/html"
 )
 
 func removeExtraSpaces(rawText string) string {
@@ -46,3 +52,357 @@ func generateFileNameFromTitle(title string) string {
 		),
 	)
 }
+
+// frontMatter holds ordered metadata fields of an article which are rendered
+// as a YAML front matter block at the top of the markdown.
+type frontMatter struct {
+	keys   []string
+	values map[string]string
+	lists  map[string][]string
+}
+
+// set records a single value metadata field. Empty values are ignored.
+func (f *frontMatter) set(key, value string) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return
+	}
+	if f.values == nil {
+		f.values = make(map[string]string)
+	}
+	if _, ok := f.values[key]; !ok {
+		f.keys = append(f.keys, key)
+	}
+	f.values[key] = value
+}
+
+// setList records a metadata field with multiple values. Empty values are
+// ignored.
+func (f *frontMatter) setList(key string, values []string) {
+	cleaned := make([]string, 0, len(values))
+	for _, v := range values {
+		if v = strings.TrimSpace(v); v != "" {
+			cleaned = append(cleaned, v)
+		}
+	}
+	if len(cleaned) == 0 {
+		return
+	}
+	if f.lists == nil {
+		f.lists = make(map[string][]string)
+	}
+	if _, ok := f.lists[key]; !ok {
+		f.keys = append(f.keys, key)
+	}
+	f.lists[key] = cleaned
+}
+
+// String renders the fields as a YAML front matter block. It returns an
+// empty string when no field has been set.
+func (f *frontMatter) String() string {
+	if len(f.keys) == 0 {
+		return ""
+	}
+	builder := strings.Builder{}
+	builder.WriteString("---\n")
+	for _, key := range f.keys {
+		if values, ok := f.lists[key]; ok {
+			builder.WriteString(fmt.Sprintf("%s:\n", key))
+			for _, v := range values {
+				builder.WriteString(fmt.Sprintf("  - %q\n", v))
+			}
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("%s: %q\n", key, f.values[key]))
+	}
+	builder.WriteString("---\n\n")
+	return builder.String()
+}
+
+// markdownHeadingSlug returns the anchor generated for a markdown heading
+// with the given text, following the GitHub convention: the text is
+// lowercased, punctuation is removed and spaces are replaced by hyphens.
+// seen tracks slugs already generated in the same document so that
+// duplicated headings get a numeric suffix.
+func markdownHeadingSlug(text string, seen map[string]int) string {
+	builder := strings.Builder{}
+	for _, r := range strings.ToLower(strings.TrimSpace(text)) {
+		switch {
+		case unicode.IsLetter(r), unicode.IsNumber(r), r == '_', r == '-':
+			builder.WriteRune(r)
+		case r == ' ':
+			builder.WriteRune('-')
+		}
+	}
+	slug := builder.String()
+	if seen == nil {
+		return slug
+	}
+	count, ok := seen[slug]
+	seen[slug] = count + 1
+	if ok {
+		return fmt.Sprintf("%s-%d", slug, count)
+	}
+	return slug
+}
+
+// renderMarkdownFigure renders an image <figure> element as a markdown image
+// followed by its caption in italics, rendered by caption or, if caption is
+// nil, as plain text. Figures without an image (such as rich links to other
+// articles) are skipped. The renderMarkdown functions are shared by the
+// scrapers of news sites, whose article bodies are plain HTML.
+func renderMarkdownFigure(e *colly.HTMLElement, caption func(*colly.HTMLElement) string) string {
+	image := renderMarkdownImage(e, e.DOM.Find("img").First())
+	if image == "" {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("%s\n\n", image))
+
+	text := ""
+	if figcaption := e.DOM.Find("figcaption").First(); figcaption.Length() > 0 {
+		if caption == nil {
+			text = figcaption.Text()
+		} else {
+			text = caption(colly.NewHTMLElementFromSelectionNode(e.Response, figcaption, figcaption.Get(0), 0))
+		}
+	}
+	text = strings.Join(strings.Fields(text), " ")
+	if text != "" {
+		builder.WriteString(fmt.Sprintf("*%s*\n\n", text))
+	}
+	return builder.String()
+}
+
+// renderMarkdownImage renders an <img> element as a markdown image with an
+// absolute URL. Line breaks in the alt text, which would end the image, are
+// collapsed. Lazily loaded images keep their URL in data-src.
+func renderMarkdownImage(e *colly.HTMLElement, img *goquery.Selection) string {
+	src := img.AttrOr("src", "")
+	if src == "" || strings.HasPrefix(src, "data:") {
+		src = img.AttrOr("data-src", "")
+	}
+	if src == "" {
+		return ""
+	}
+	alt := strings.Join(strings.Fields(img.AttrOr("alt", "")), " ")
+	return fmt.Sprintf("![%s](%s)", alt, e.Request.AbsoluteURL(src))
+}
+
+// renderMarkdownList renders a <ul> or <ol> element as markdown.
+func renderMarkdownList(e *colly.HTMLElement, ordered bool) string {
+	builder := strings.Builder{}
+	index := 0
+	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
+		if !li.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		index++
+
+		// List items may contain <p> children or bare text.
+		parts := make([]string, 0)
+		li.ForEach("p", func(_ int, p *colly.HTMLElement) {
+			if !p.DOM.Parent().IsSelection(li.DOM) {
+				return
+			}
+			if text := strings.TrimSpace(renderMarkdownInline(p)); text != "" {
+				parts = append(parts, text)
+			}
+		})
+		text := strings.Join(parts, " ")
+		if len(parts) == 0 {
+			text = strings.TrimSpace(renderMarkdownInline(li))
+		}
+
+		if ordered {
+			builder.WriteString(fmt.Sprintf("%d. %s\n", index, text))
+		} else {
+			builder.WriteString(fmt.Sprintf("* %s\n", text))
+		}
+	})
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// renderMarkdownInline renders the inline content of an element as markdown,
+// preserving <a> as markdown links with absolute URLs, <b>/<strong> as bold,
+// and <i>/<em> as italic.
+func renderMarkdownInline(e *colly.HTMLElement) string {
+	return renderMarkdownInlineNodes(e, e.DOM.Contents())
+}
+
+// renderMarkdownInlineNodes renders the nodes of a selection as inline
+// markdown, recursing into nested inline elements.
+func renderMarkdownInlineNodes(e *colly.HTMLElement, sel *goquery.Selection) string {
+	builder := strings.Builder{}
+	for i, node := range sel.Nodes {
+		switch node.Type {
+		case html.TextNode:
+			builder.WriteString(node.Data)
+		case html.ElementNode:
+			child := sel.Eq(i)
+			switch node.Data {
+			case "a":
+				href, _ := child.Attr("href")
+				linkText := strings.TrimSpace(renderMarkdownInlineNodes(e, child.Contents()))
+				if href != "" && linkText != "" {
+					builder.WriteString(fmt.Sprintf("[%s](%s)", linkText, e.Request.AbsoluteURL(href)))
+				} else {
+					builder.WriteString(linkText)
+				}
+			case "b", "strong":
+				trimmed := strings.TrimSpace(renderMarkdownInlineNodes(e, child.Contents()))
+				if trimmed != "" {
+					builder.WriteString(fmt.Sprintf("**%s**", trimmed))
+				}
+			case "i", "em":
+				trimmed := strings.TrimSpace(renderMarkdownInlineNodes(e, child.Contents()))
+				if trimmed != "" {
+					builder.WriteString(fmt.Sprintf("*%s*", trimmed))
+				}
+			case "br":
+				builder.WriteString("  \n")
+			default:
+				builder.WriteString(renderMarkdownInlineNodes(e, child.Contents()))
+			}
+		}
+	}
+	return builder.String()
+}
+
+// renderMarkdownBlockquote renders a <blockquote> element as a markdown
+// block quote, one quoted paragraph per <p> child, with the content of each
+// paragraph rendered by inline.
+func renderMarkdownBlockquote(e *colly.HTMLElement, inline func(*colly.HTMLElement) string) string {
+	paragraphs := make([]string, 0)
+	e.ForEach("p", func(_ int, p *colly.HTMLElement) {
+		if text := strings.TrimSpace(inline(p)); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	})
+	if len(paragraphs) == 0 {
+		if text := strings.TrimSpace(inline(e)); text != "" {
+			paragraphs = append(paragraphs, text)
+		}
+	}
+	if len(paragraphs) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("> %s\n\n", strings.Join(paragraphs, "\n>\n> "))
+}
+
+// renderMarkdownTable renders a <table> element as a markdown table, with
+// the content of each cell rendered by inline. The first row is used as the
+// header row.
+func renderMarkdownTable(table *colly.HTMLElement, inline func(*colly.HTMLElement) string) string {
+	builder := strings.Builder{}
+	rowIndex := 0
+	table.ForEach("tr", func(_ int, tr *colly.HTMLElement) {
+		cells := make([]string, 0)
+		tr.ForEach("th, td", func(_ int, cell *colly.HTMLElement) {
+			if !cell.DOM.Parent().IsSelection(tr.DOM) {
+				return
+			}
+			text := strings.Join(strings.Fields(inline(cell)), " ")
+			cells = append(cells, strings.ReplaceAll(text, "|", "\\|"))
+		})
+		if len(cells) == 0 {
+			return
+		}
+
+		builder.WriteString("| ")
+		builder.WriteString(strings.Join(cells, " | "))
+		builder.WriteString(" |\n")
+
+		if rowIndex == 0 {
+			builder.WriteString("|")
+			for range cells {
+				builder.WriteString(" --- |")
+			}
+			builder.WriteString("\n")
+		}
+		rowIndex++
+	})
+	if builder.Len() > 0 {
+		builder.WriteString("\n")
+	}
+	return builder.String()
+}
+
+// quoteMarkdownCallout renders the markdown of a callout, such as a note or
+// a warning, as a blockquote starting with its label in bold. It returns an
+// empty string if the callout has no content.
+func quoteMarkdownCallout(label string, content string) string {
+	content = strings.TrimSpace(content)
+	if content == "" {
+		return ""
+	}
+
+	builder := strings.Builder{}
+	builder.WriteString(fmt.Sprintf("> **%s**\n>\n", label))
+	for _, line := range strings.Split(content, "\n") {
+		if line == "" {
+			builder.WriteString(">\n")
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("> %s\n", line))
+	}
+	builder.WriteString("\n")
+	return builder.String()
+}
+
+// ariaTab is a tab of a tab group with the panel of its content.
+type ariaTab struct {
+	label string
+	panel *colly.HTMLElement
+}
+
+// parseAriaTabs returns the tabs of a group marked up with the tablist, tab
+// and tabpanel roles, where the tab list and the panels are children of the
+// group. A panel is labelled by the tab of its aria-labelledby attribute, or
+// else by the tab at the same position.
+func parseAriaTabs(e *colly.HTMLElement) []ariaTab {
+	tabs := e.DOM.ChildrenFiltered("[role='tablist']").Find("[role='tab']")
+	labels := make(map[string]string)
+	for i := range tabs.Nodes {
+		tab := tabs.Eq(i)
+		if id := tab.AttrOr("id", ""); id != "" {
+			labels[id] = strings.TrimSpace(tab.Text())
+		}
+	}
+
+	var panels []ariaTab
+	index := 0
+	e.ForEach("[role='tabpanel']", func(_ int, panel *colly.HTMLElement) {
+		// panels of nested tab groups belong to the nested groups
+		if !panel.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		label, ok := labels[panel.Attr("aria-labelledby")]
+		if !ok {
+			label = strings.TrimSpace(tabs.Eq(index).Text())
+		}
+		panels = append(panels, ariaTab{label: label, panel: panel})
+		index++
+	})
+	return panels
+}
+
+// renderMarkdownTabs renders each tab whose panel has content as a
+// subsection of the heading level, titled by the label of the tab. The
+// panels are rendered by render.
+func renderMarkdownTabs(tabs []ariaTab, level int, render func(*colly.HTMLElement) string) string {
+	builder := strings.Builder{}
+	for _, tab := range tabs {
+		content := render(tab.panel)
+		if strings.TrimSpace(content) == "" {
+			continue
+		}
+		if tab.label != "" {
+			builder.WriteString(fmt.Sprintf("%s %s\n\n", strings.Repeat("#", level), tab.label))
+		}
+		builder.WriteString(content)
+	}
+	return builder.String()
+}
@@ -265,3 +265,125 @@ func TestGenerateFileNameFromTitle(t *testing.T) {
 		})
 	}
 }
+
+func TestFrontMatter(t *testing.T) {
+	tests := []struct {
+		name     string
+		build    func(f *frontMatter)
+		expected string
+	}{
+		{
+			name:     "no fields",
+			build:    func(f *frontMatter) {},
+			expected: "",
+		},
+		{
+			name: "empty values are ignored",
+			build: func(f *frontMatter) {
+				f.set("author", " ")
+				f.setList("tags", []string{"", " "})
+			},
+			expected: "",
+		},
+		{
+			name: "fields keep insertion order",
+			build: func(f *frontMatter) {
+				f.set("version", "v1.0.0")
+				f.set("license", "MIT")
+			},
+			expected: "---\nversion: \"v1.0.0\"\nlicense: \"MIT\"\n---\n\n",
+		},
+		{
+			name: "overwriting keeps original position",
+			build: func(f *frontMatter) {
+				f.set("version", "v1.0.0")
+				f.set("license", "MIT")
+				f.set("version", "v2.0.0")
+			},
+			expected: "---\nversion: \"v2.0.0\"\nlicense: \"MIT\"\n---\n\n",
+		},
+		{
+			name: "values are quoted",
+			build: func(f *frontMatter) {
+				f.set("title", `Say "hello": world`)
+			},
+			expected: "---\ntitle: \"Say \\\"hello\\\": world\"\n---\n\n",
+		},
+		{
+			name: "list values",
+			build: func(f *frontMatter) {
+				f.setList("authors", []string{"Alice", "Bob"})
+			},
+			expected: "---\nauthors:\n  - \"Alice\"\n  - \"Bob\"\n---\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f := frontMatter{}
+			tt.build(&f)
+			result := f.String()
+			if result != tt.expected {
+				t.Errorf("frontMatter.String() = %q, want %q", result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownHeadingSlug(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "simple heading",
+			input:    "Hello World",
+			expected: "hello-world",
+		},
+		{
+			name:     "punctuation removed",
+			input:    "Go 1.22: What's new?",
+			expected: "go-122-whats-new",
+		},
+		{
+			name:     "slash removed",
+			input:    "net/http",
+			expected: "nethttp",
+		},
+		{
+			name:     "hyphens and underscores preserved",
+			input:    "step-by-step snake_case",
+			expected: "step-by-step-snake_case",
+		},
+		{
+			name:     "unicode letters preserved",
+			input:    "Go 言語",
+			expected: "go-言語",
+		},
+		{
+			name:     "surrounding spaces trimmed",
+			input:    "  Title  ",
+			expected: "title",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := markdownHeadingSlug(tt.input, nil)
+			if result != tt.expected {
+				t.Errorf("markdownHeadingSlug(%q) = %q, want %q", tt.input, result, tt.expected)
+			}
+		})
+	}
+}
+
+func TestMarkdownHeadingSlug_Duplicates(t *testing.T) {
+	seen := make(map[string]int)
+	expected := []string{"example", "example-1", "example-2"}
+	for _, want := range expected {
+		if result := markdownHeadingSlug("Example", seen); result != want {
+			t.Errorf("markdownHeadingSlug(%q) = %q, want %q", "Example", result, want)
+		}
+	}
+}
@@ -2,6 +2,9 @@ package scraper
 
 import (
 	"fmt"
+	"net/url"
+	"slices"
+	"strconv"
 	"strings"
 
 	"github.com/PuerkitoBio/goquery"
@@ -10,37 +13,168 @@ import (
 )
 
 type WikipediaScraper struct {
+	// SkipSections overrides the built-in headings of sections to be
+	// excluded from the output, keyed by language code.
+	SkipSections map[string][]string
+	// Footnotes converts citations into markdown footnotes instead of
+	// removing them.
+	Footnotes bool
+	// API fetches articles through the MediaWiki Action API of the wiki
+	// instead of scraping the rendered pages.
+	API bool
+	// Revision is the ID of the revision to be fetched through the API
+	// instead of the latest one.
+	Revision int64
+	// Sections limits the output to the lead and the sections of the given
+	// headings, including sections which are skipped by default.
+	Sections []string
+	// ExcludeSections are headings of sections to be skipped in addition to
+	// the default ones.
+	ExcludeSections []string
+	// LeadOnly limits the output to the lead section.
+	LeadOnly bool
+	// MaxHeadingLevel skips sections with headings deeper than the level,
+	// e.g. 2 keeps only the top level sections. Zero means no limit.
+	MaxHeadingLevel int
+	// CategoryDepth is the number of levels of subcategories whose member
+	// pages are included when scraping links of a category.
+	CategoryDepth int
+}
+
+// WikipediaArticle is the structured content of a Wikipedia article.
+type WikipediaArticle struct {
+	Title string `json:"title"`
+	// Revision, Updated, Categories and RedirectedFrom are only available
+	// when the article is fetched through the API.
+	Revision       int64             `json:"revision,omitempty"`
+	Updated        string            `json:"updated,omitempty"`
+	Categories     []string          `json:"categories,omitempty"`
+	RedirectedFrom string            `json:"redirected_from,omitempty"`
+	Infobox        *WikipediaInfobox `json:"infobox,omitempty"`
+	// Content is the body of the article in markdown.
+	Content string `json:"content"`
+}
+
+// WikipediaInfobox is the summary table shown at the top right of an
+// article.
+type WikipediaInfobox struct {
+	Title  string                  `json:"title,omitempty"`
+	Images []WikipediaImage        `json:"images,omitempty"`
+	Fields []WikipediaInfoboxField `json:"fields,omitempty"`
+}
+
+// WikipediaImage is an image of an article.
+type WikipediaImage struct {
+	URL     string `json:"url"`
+	Alt     string `json:"alt,omitempty"`
+	Caption string `json:"caption,omitempty"`
+}
+
+// WikipediaInfoboxField is a labelled row of an infobox. Section is the
+// header row the field belongs to, if any. Label and Value are in markdown
+// and lines of a multi-line value are separated by "\n".
+type WikipediaInfoboxField struct {
+	Section string `json:"section,omitempty"`
+	Label   string `json:"label"`
+	Value   string `json:"value"`
 }
 
 // ScrapeArticle scrapes the article content from the specified URL
 // and returns markdown in a string
 func (w *WikipediaScraper) ScrapeArticle(url string) (string, error) {
+	article, err := w.scrape(url)
+	if err != nil {
+		return "", err
+	}
+
+	metadata := frontMatter{}
+	if article.Revision != 0 {
+		metadata.set("revision", strconv.FormatInt(article.Revision, 10))
+	}
+	metadata.set("updated", article.Updated)
+	metadata.set("redirected_from", article.RedirectedFrom)
+	metadata.setList("categories", article.Categories)
+
+	markdown := metadata.String()
+	if article.Title != "" {
+		markdown += fmt.Sprintf("# %s\n\n", article.Title)
+	}
+	if article.Infobox != nil {
+		markdown += article.Infobox.markdown()
+	}
+	markdown += article.Content
+
+	return markdown, nil
+}
+
+// ScrapeData scrapes the article from the specified URL and returns it as a
+// WikipediaArticle.
+func (w *WikipediaScraper) ScrapeData(url string) (any, error) {
+	return w.scrape(url)
+}
+
+func (w *WikipediaScraper) scrape(url string) (*WikipediaArticle, error) {
+	if w.API || w.Revision != 0 {
+		return w.scrapeAPI(url)
+	}
+
 	collector := colly.NewCollector()
 
-	var markdown string
+	article := &WikipediaArticle{}
+	language := getWikipediaLanguage(url)
+
+	// language of the page
+	collector.OnHTML("html[lang]", func(e *colly.HTMLElement) {
+		language = e.Attr("lang")
+	})
 
 	// title — h1#firstHeading is outside div.mw-parser-output
 	collector.OnHTML("h1#firstHeading", func(e *colly.HTMLElement) {
-		text := strings.TrimSpace(e.Text)
-		if text != "" {
-			markdown += fmt.Sprintf("# %s\n\n", text)
-		}
+		article.Title = strings.TrimSpace(e.Text)
 	})
 
 	// article body
 	collector.OnHTML("div#mw-content-text > div.mw-parser-output", func(e *colly.HTMLElement) {
-		markdown += parseWikipediaContent(e)
+		w.parseBody(e, language, article)
 	})
 
 	err := collector.Visit(url)
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return markdown, nil
+	return article, nil
+}
+
+// parseBody parses the infobox and the content of an article body
+// (div.mw-parser-output) into article.
+func (w *WikipediaScraper) parseBody(e *colly.HTMLElement, language string, article *WikipediaArticle) {
+	e.ForEach("table.infobox", func(_ int, infobox *colly.HTMLElement) {
+		if article.Infobox == nil {
+			article.Infobox = parseWikipediaInfobox(infobox)
+		}
+	})
+
+	var citations []wikipediaCitation
+	if w.Footnotes {
+		citations = replaceWikipediaCitations(e)
+	}
+	content := parseWikipediaContent(e, w.sectionFilter(language))
+	article.Content += content
+	if w.Footnotes {
+		article.Content += parseWikipediaFootnotes(e, citations, content)
+	}
 }
 
 func (w *WikipediaScraper) ScrapeTitle(url string) (string, error) {
+	if w.API || w.Revision != 0 {
+		article, err := w.scrapeAPI(url)
+		if err != nil {
+			return "", err
+		}
+		return article.Title, nil
+	}
+
 	collector := colly.NewCollector()
 
 	var title string
@@ -61,27 +195,177 @@ func (w *WikipediaScraper) ScrapeFilename(url string) (string, error) {
 	return getBasenameFromURL(url), nil
 }
 
-// wikipediaSkipSections contains section headings whose content should be
-// excluded from the scraped output.
-var wikipediaSkipSections = map[string]bool{
-	"References":       true,
-	"Further reading":  true,
-	"External links":   true,
-	"See also":         true,
-	"Notes":            true,
-	"Citations":        true,
-	"Bibliography":     true,
-	"Sources":          true,
-	"Cited sources":    true,
-	"Works cited":      true,
-	"Cited references": true,
+// wikipediaSkipSections contains, for each language edition, the section
+// headings whose content should be excluded from the scraped output.
+var wikipediaSkipSections = map[string][]string{
+	"en": {
+		"References",
+		"Further reading",
+		"External links",
+		"See also",
+		"Notes",
+		"Citations",
+		"Bibliography",
+		"Sources",
+		"Cited sources",
+		"Works cited",
+		"Cited references",
+	},
+	"ja": {
+		"脚注",
+		"注釈",
+		"出典",
+		"参考文献",
+		"関連文献",
+		"関連項目",
+		"外部リンク",
+	},
+	"de": {
+		"Einzelnachweise",
+		"Anmerkungen",
+		"Literatur",
+		"Weblinks",
+		"Siehe auch",
+		"Quellen",
+	},
+	"fr": {
+		"Notes et références",
+		"Notes",
+		"Références",
+		"Bibliographie",
+		"Voir aussi",
+		"Articles connexes",
+		"Liens externes",
+	},
+	"es": {
+		"Referencias",
+		"Notas",
+		"Bibliografía",
+		"Véase también",
+		"Enlaces externos",
+		"Fuentes",
+	},
+	"zh": {
+		"参考文献",
+		"參考文獻",
+		"参考资料",
+		"參考資料",
+		"注释",
+		"註釋",
+		"延伸阅读",
+		"延伸閱讀",
+		"参见",
+		"參見",
+		"外部链接",
+		"外部連結",
+	},
+}
+
+// skipSections returns the set of section headings to be skipped for the
+// given language. Languages without a list fall back to English.
+func (w *WikipediaScraper) skipSections(language string) map[string]bool {
+	language = strings.ToLower(language)
+	// regional variants such as zh-hant share the list of the language
+	if base, _, found := strings.Cut(language, "-"); found {
+		language = base
+	}
+
+	headings, ok := w.SkipSections[language]
+	if !ok {
+		headings, ok = wikipediaSkipSections[language]
+	}
+	if !ok {
+		headings = wikipediaSkipSections["en"]
+	}
+
+	sections := make(map[string]bool, len(headings))
+	for _, heading := range headings {
+		sections[heading] = true
+	}
+	return sections
+}
+
+// getWikipediaLanguage returns the language code of a Wikipedia URL,
+// e.g. https://ja.wikipedia.org/wiki/... → "ja". It returns "en" when the
+// host is not a Wikipedia language edition.
+func getWikipediaLanguage(rawURL string) string {
+	parsed, err := url.Parse(rawURL)
+	if err != nil {
+		return "en"
+	}
+	host := parsed.Hostname()
+	if !strings.HasSuffix(host, ".wikipedia.org") {
+		return "en"
+	}
+	language, _, _ := strings.Cut(host, ".")
+	if language == "www" || language == "m" {
+		return "en"
+	}
+	return language
+}
+
+// wikipediaSectionFilter decides which sections of an article are rendered.
+type wikipediaSectionFilter struct {
+	// skip contains the headings of sections skipped by default
+	skip map[string]bool
+	// include and exclude contain lower-cased headings of sections selected
+	// by the user
+	include  map[string]bool
+	exclude  map[string]bool
+	leadOnly bool
+	maxLevel int
+}
+
+// sectionFilter returns the filter of sections for the given language.
+func (w *WikipediaScraper) sectionFilter(language string) wikipediaSectionFilter {
+	filter := wikipediaSectionFilter{
+		skip:     w.skipSections(language),
+		include:  make(map[string]bool),
+		exclude:  make(map[string]bool),
+		leadOnly: w.LeadOnly,
+		maxLevel: w.MaxHeadingLevel,
+	}
+	for _, heading := range w.Sections {
+		filter.include[strings.ToLower(strings.TrimSpace(heading))] = true
+	}
+	for _, heading := range w.ExcludeSections {
+		filter.exclude[strings.ToLower(strings.TrimSpace(heading))] = true
+	}
+	return filter
+}
+
+// excludes returns true when the section of a heading and its subsections
+// are to be skipped.
+func (f wikipediaSectionFilter) excludes(heading string, level int) bool {
+	name := strings.ToLower(heading)
+	if f.include[name] {
+		return false
+	}
+	return f.skip[heading] ||
+		f.exclude[name] ||
+		(f.maxLevel > 0 && level > f.maxLevel)
+}
+
+// wikipediaAncestorHeading is the heading of a section enclosing the section
+// being rendered. written is true if the heading has been rendered.
+type wikipediaAncestorHeading struct {
+	level   int
+	heading string
+	written bool
 }
 
-func parseWikipediaContent(e *colly.HTMLElement) string {
+func parseWikipediaContent(e *colly.HTMLElement, filter wikipediaSectionFilter) string {
 	builder := strings.Builder{}
 
-	// Track whether we are inside a section that should be skipped.
-	skipping := false
+	// Content before the first heading (the lead) is always rendered.
+	emitting := true
+	// Level of the heading of the section being skipped with its
+	// subsections, or zero.
+	skipLevel := 0
+	// Level of the heading of the section selected by the user, or zero.
+	includeLevel := 0
+	// Headings of the sections enclosing the current heading.
+	var ancestors []wikipediaAncestorHeading
 
 	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
 		if !child.DOM.Parent().IsSelection(e.DOM) {
@@ -94,26 +378,58 @@ func parseWikipediaContent(e *colly.HTMLElement) string {
 			if heading == "" {
 				return
 			}
-
-			// Check if this section should be skipped.
 			headingText := extractWikipediaHeadingText(child)
-			if wikipediaSkipSections[headingText] {
-				skipping = true
+			level := wikipediaHeadingLevel(child)
+
+			// subsections of a skipped section are skipped
+			if skipLevel > 0 && level > skipLevel {
 				return
 			}
-
-			// A non-skipped heading resets skipping for mw-heading2 level.
-			if child.DOM.HasClass("mw-heading2") {
-				skipping = false
+			skipLevel = 0
+			if includeLevel > 0 && level <= includeLevel {
+				includeLevel = 0
+			}
+			for len(ancestors) > 0 && ancestors[len(ancestors)-1].level >= level {
+				ancestors = ancestors[:len(ancestors)-1]
 			}
 
-			if !skipping {
-				builder.WriteString(heading)
+			if filter.leadOnly {
+				emitting = false
+				skipLevel = 1
+				return
+			}
+			if filter.excludes(headingText, level) {
+				emitting = false
+				skipLevel = level
+				return
+			}
+			// sections not selected are skipped but their subsections may
+			// be selected
+			if len(filter.include) > 0 && includeLevel == 0 {
+				if !filter.include[strings.ToLower(headingText)] {
+					emitting = false
+					ancestors = append(ancestors, wikipediaAncestorHeading{level: level, heading: heading})
+					return
+				}
+				includeLevel = level
+				// a selected subsection is rendered under the headings of
+				// its sections, so that it is not read as a part of the
+				// section rendered before it
+				for i := range ancestors {
+					if !ancestors[i].written {
+						builder.WriteString(ancestors[i].heading)
+						ancestors[i].written = true
+					}
+				}
 			}
+
+			emitting = true
+			builder.WriteString(heading)
+			ancestors = append(ancestors, wikipediaAncestorHeading{level: level, heading: heading, written: true})
 			return
 		}
 
-		if skipping {
+		if !emitting {
 			return
 		}
 
@@ -143,10 +459,27 @@ func parseWikipediaContent(e *colly.HTMLElement) string {
 			if text != "" {
 				builder.WriteString(fmt.Sprintf("> %s\n\n", text))
 			}
+		case "dl":
+			// indented display formulas
+			if isWikipediaMathList(child) {
+				child.ForEach("span.mwe-math-element", func(_ int, math *colly.HTMLElement) {
+					builder.WriteString(strings.TrimSpace(parseWikipediaMath(math.DOM)) + "\n\n")
+				})
+				return
+			}
+			builder.WriteString(parseWikipediaDefinitionList(child))
+		case "div":
+			// notes linking to other articles, e.g. "For other uses, see ..."
+			if child.DOM.HasClass("hatnote") || child.DOM.HasClass("dablink") {
+				text := strings.Join(strings.Fields(parseWikipediaInline(child)), " ")
+				if text != "" {
+					builder.WriteString(fmt.Sprintf("*%s*\n\n", text))
+				}
+			}
 		case "figure":
 			parseWikipediaFigure(child, &builder)
 		case "table":
-			if child.DOM.HasClass("wikitable") {
+			if isWikipediaDataTable(child.DOM) {
 				builder.WriteString(parseWikipediaTable(child))
 			}
 		}
@@ -183,6 +516,18 @@ func parseWikipediaHeading(div *colly.HTMLElement) string {
 	return result
 }
 
+// wikipediaHeadingLevel returns the level of the heading element inside a
+// mw-heading div, e.g. 2 for <h2>.
+func wikipediaHeadingLevel(div *colly.HTMLElement) int {
+	level := 0
+	div.ForEach("h2, h3, h4, h5, h6", func(_ int, h *colly.HTMLElement) {
+		if level == 0 {
+			level = int(h.Name[1] - '0')
+		}
+	})
+	return level
+}
+
 // extractWikipediaHeadingText returns the plain text of the heading element
 // inside a mw-heading div, stripping edit-section links.
 func extractWikipediaHeadingText(div *colly.HTMLElement) string {
@@ -212,16 +557,28 @@ func parseWikipediaFigure(e *colly.HTMLElement, builder *strings.Builder) {
 		if src == "" {
 			return
 		}
-		// Wikipedia uses protocol-relative URLs (//upload.wikimedia.org/...)
-		if strings.HasPrefix(src, "//") {
-			src = "https:" + src
-		}
-		builder.WriteString(fmt.Sprintf("![%s](%s)\n\n", alt, src))
+		builder.WriteString(fmt.Sprintf("![%s](%s)\n\n", alt, wikipediaImageURL(img, src)))
 	})
 }
 
+// wikipediaImageURL returns the absolute URL of an image source. Wikipedia
+// uses protocol-relative URLs (//upload.wikimedia.org/...).
+func wikipediaImageURL(e *colly.HTMLElement, src string) string {
+	if strings.HasPrefix(src, "//") {
+		return "https:" + src
+	}
+	return e.Request.AbsoluteURL(src)
+}
+
 // parseWikipediaList renders a <ul> or <ol> element as markdown.
 func parseWikipediaList(e *colly.HTMLElement, ordered bool) string {
+	return parseWikipediaListItems(e, ordered, "") + "\n"
+}
+
+// parseWikipediaListItems renders the items of a list indented by indent.
+// Nested lists are rendered below their items, indented to the content of
+// the items.
+func parseWikipediaListItems(e *colly.HTMLElement, ordered bool, indent string) string {
 	builder := strings.Builder{}
 	index := 0
 	e.ForEach("li", func(_ int, li *colly.HTMLElement) {
@@ -230,14 +587,87 @@ func parseWikipediaList(e *colly.HTMLElement, ordered bool) string {
 		}
 		index++
 
-		text := strings.TrimSpace(parseWikipediaInline(li))
+		marker := "* "
 		if ordered {
-			builder.WriteString(fmt.Sprintf("%d. %s\n", index, text))
-		} else {
-			builder.WriteString(fmt.Sprintf("* %s\n", text))
+			marker = fmt.Sprintf("%d. ", index)
 		}
+		text := strings.TrimSpace(parseWikipediaInlineSelection(li, li.DOM.Contents().Not("ul, ol, dl")))
+		builder.WriteString(fmt.Sprintf("%s%s%s\n", indent, marker, text))
+
+		builder.WriteString(parseWikipediaNestedLists(li, indent+strings.Repeat(" ", len(marker))))
 	})
-	builder.WriteString("\n")
+	return builder.String()
+}
+
+// parseWikipediaNestedLists renders the lists directly inside a list item or
+// a description, indented by indent. Items of nested definition lists are
+// rendered as bullets.
+func parseWikipediaNestedLists(e *colly.HTMLElement, indent string) string {
+	builder := strings.Builder{}
+	e.ForEach("ul, ol, dl", func(_ int, list *colly.HTMLElement) {
+		if !list.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		switch list.Name {
+		case "ul":
+			builder.WriteString(parseWikipediaListItems(list, false, indent))
+		case "ol":
+			builder.WriteString(parseWikipediaListItems(list, true, indent))
+		case "dl":
+			list.ForEach("dt, dd", func(_ int, item *colly.HTMLElement) {
+				if !item.DOM.Parent().IsSelection(list.DOM) {
+					return
+				}
+				text := strings.TrimSpace(parseWikipediaInlineSelection(item, item.DOM.Contents().Not("ul, ol, dl")))
+				if text != "" {
+					if item.Name == "dt" {
+						text = fmt.Sprintf("**%s**", text)
+					}
+					builder.WriteString(fmt.Sprintf("%s* %s\n", indent, text))
+				}
+				builder.WriteString(parseWikipediaNestedLists(item, indent+"  "))
+			})
+		}
+	})
+	return builder.String()
+}
+
+// parseWikipediaDefinitionList renders a <dl> element as terms in bold, each
+// followed by its descriptions prefixed with a colon. Descriptions without
+// terms, which Wikipedia uses for indentation, are rendered as paragraphs.
+func parseWikipediaDefinitionList(e *colly.HTMLElement) string {
+	builder := strings.Builder{}
+	hasTerms := e.DOM.ChildrenFiltered("dt").Length() > 0
+	e.ForEach("dt, dd", func(i int, item *colly.HTMLElement) {
+		if !item.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+		text := strings.TrimSpace(parseWikipediaInlineSelection(item, item.DOM.Contents().Not("ul, ol, dl")))
+		nested := parseWikipediaNestedLists(item, "  ")
+
+		switch {
+		case !hasTerms:
+			if text != "" {
+				builder.WriteString(fmt.Sprintf("%s\n\n", text))
+			}
+			if nested != "" {
+				builder.WriteString(nested + "\n")
+			}
+		case item.Name == "dt":
+			// groups of a term and its descriptions are separated by blank
+			// lines
+			if builder.Len() > 0 {
+				builder.WriteString("\n")
+			}
+			builder.WriteString(fmt.Sprintf("**%s**\n", text))
+		default:
+			builder.WriteString(fmt.Sprintf(": %s\n", text))
+			builder.WriteString(nested)
+		}
+	})
+	if hasTerms {
+		builder.WriteString("\n")
+	}
 	return builder.String()
 }
 
@@ -245,13 +675,19 @@ func parseWikipediaList(e *colly.HTMLElement, ordered bool) string {
 // stripping citation superscripts and edit-section links while preserving
 // bold, italic, code, and anchor elements.
 func parseWikipediaInline(e *colly.HTMLElement) string {
+	return parseWikipediaInlineSelection(e, e.DOM.Contents())
+}
+
+// parseWikipediaInlineSelection renders the nodes of a selection, which
+// belong to the element e, as inline markdown.
+func parseWikipediaInlineSelection(e *colly.HTMLElement, contents *goquery.Selection) string {
 	builder := strings.Builder{}
-	for _, node := range e.DOM.Contents().Nodes {
+	for i, node := range contents.Nodes {
 		switch node.Type {
 		case html.TextNode:
 			builder.WriteString(node.Data)
 		case html.ElementNode:
-			sel := e.DOM.FindNodes(node)
+			sel := contents.Eq(i)
 
 			// Skip elements that should be excluded.
 			if shouldSkipWikipediaNode(node, sel) {
@@ -269,9 +705,10 @@ func parseWikipediaInline(e *colly.HTMLElement) string {
 					continue
 				}
 				if href != "" {
-					// Convert relative wiki links to absolute URLs.
+					// Convert relative wiki links to absolute URLs of the
+					// language edition being scraped.
 					if strings.HasPrefix(href, "/wiki/") {
-						href = "https://en.wikipedia.org" + href
+						href = e.Request.AbsoluteURL(href)
 					}
 					builder.WriteString(fmt.Sprintf("[%s](%s)", linkText, href))
 				} else {
@@ -289,7 +726,14 @@ func parseWikipediaInline(e *colly.HTMLElement) string {
 				if trimmed != "" {
 					builder.WriteString(fmt.Sprintf("*%s*", trimmed))
 				}
+			case "cite":
+				// Citations of references contain links to the sources.
+				builder.WriteString(parseWikipediaInlineSelection(e, sel.Contents()))
 			case "span":
+				if sel.HasClass("mwe-math-element") {
+					builder.WriteString(parseWikipediaMath(sel))
+					continue
+				}
 				// Skip mw-editsection spans, shortdescription spans, and
 				// metadata spans. For other spans, emit their text.
 				if sel.HasClass("mw-editsection") ||
@@ -309,6 +753,12 @@ func parseWikipediaInline(e *colly.HTMLElement) string {
 // shouldSkipWikipediaNode returns true for elements that should be excluded
 // from the markdown output.
 func shouldSkipWikipediaNode(node *html.Node, sel *goquery.Selection) bool {
+	// Skip hidden content such as the sort keys of sortable tables.
+	style := strings.ReplaceAll(sel.AttrOr("style", ""), " ", "")
+	if strings.Contains(style, "display:none") || sel.HasClass("sortkey") {
+		return true
+	}
+
 	switch node.Data {
 	case "sup":
 		// Skip citation references [1], [2] etc and "citation needed" tags.
@@ -320,7 +770,8 @@ func shouldSkipWikipediaNode(node *html.Node, sel *goquery.Selection) bool {
 	case "span":
 		if sel.HasClass("mw-editsection") ||
 			sel.HasClass("shortdescription") ||
-			sel.HasClass("Z3988") {
+			sel.HasClass("Z3988") ||
+			sel.HasClass("mw-cite-backlink") {
 			return true
 		}
 	case "div":
@@ -408,45 +859,571 @@ func parseWikipediaInlineNodes(nodes []*html.Node) string {
 	return builder.String()
 }
 
-// parseWikipediaTable renders a Wikipedia table with class "wikitable" as
-// a markdown table.
+// wikipediaNonDataTableClasses contains classes of tables used for layout,
+// navigation and maintenance messages rather than data.
+var wikipediaNonDataTableClasses = []string{
+	"infobox",
+	"navbox",
+	"vertical-navbox",
+	"sidebar",
+	"metadata",
+	"ambox",
+	"mbox-small",
+	"sistersitebox",
+	"noprint",
+}
+
+// wikipediaMaxSpan is the maximum number of rows or columns a cell can span,
+// to guard against malformed rowspan and colspan attributes.
+const wikipediaMaxSpan = 100
+
+// wikipediaTableCell is a cell of the grid of a table. Cells spanning
+// multiple rows or columns are repeated in every position they cover.
+type wikipediaTableCell struct {
+	text   string
+	header bool
+}
+
+// isWikipediaDataTable returns true when a table contains data to be
+// rendered, as opposed to infoboxes, navigation boxes and message boxes.
+func isWikipediaDataTable(table *goquery.Selection) bool {
+	for _, class := range wikipediaNonDataTableClasses {
+		if table.HasClass(class) {
+			return false
+		}
+	}
+	return true
+}
+
+// parseWikipediaTable renders a table as a markdown table preceded by its
+// caption. Merged cells are expanded into a grid, and leading header rows
+// are combined into a single header row. Tables containing nested tables
+// cannot be expressed in markdown and are rendered as HTML.
 func parseWikipediaTable(table *colly.HTMLElement) string {
+	rows := wikipediaTableRows(table.DOM)
+	if len(rows) == 0 {
+		return ""
+	}
+	for _, row := range rows {
+		if row.Find("table").Length() > 0 {
+			return parseWikipediaHTMLTable(table)
+		}
+	}
+
+	grid := parseWikipediaTableGrid(table, rows)
+	width := 0
+	for _, row := range grid {
+		width = max(width, len(row))
+	}
+	if width == 0 {
+		return ""
+	}
+
+	// leading rows consisting of header cells only form the header
+	headerRows := 0
+	for _, row := range grid {
+		if len(row) == 0 || slices.ContainsFunc(row, func(c wikipediaTableCell) bool { return !c.header }) {
+			break
+		}
+		headerRows++
+	}
+	// markdown tables require a header, so the first row is used otherwise
+	headerRows = max(headerRows, 1)
+	if headerRows == len(grid) {
+		headerRows = 1
+	}
+
+	header := make([]string, width)
+	for column := range width {
+		parts := make([]string, 0, headerRows)
+		for _, row := range grid[:headerRows] {
+			if column >= len(row) || row[column].text == "" {
+				continue
+			}
+			// cells spanning multiple header rows appear once
+			if len(parts) > 0 && parts[len(parts)-1] == row[column].text {
+				continue
+			}
+			parts = append(parts, row[column].text)
+		}
+		header[column] = strings.Join(parts, " – ")
+	}
+
 	builder := strings.Builder{}
-	rowIndex := 0
+	caption := table.DOM.ChildrenFiltered("caption").First()
+	if caption.Length() > 0 {
+		captionElement := colly.NewHTMLElementFromSelectionNode(table.Response, caption, caption.Get(0), 0)
+		text := strings.Join(strings.Fields(parseWikipediaInline(captionElement)), " ")
+		if text != "" {
+			builder.WriteString(fmt.Sprintf("*%s*\n\n", text))
+		}
+	}
+
+	writeRow := func(cells []string) {
+		builder.WriteString("|")
+		for column := range width {
+			text := ""
+			if column < len(cells) {
+				text = cells[column]
+			}
+			builder.WriteString(fmt.Sprintf(" %s |", text))
+		}
+		builder.WriteString("\n")
+	}
+
+	writeRow(header)
+	builder.WriteString("|")
+	for range width {
+		builder.WriteString(" --- |")
+	}
+	builder.WriteString("\n")
+	for _, row := range grid[headerRows:] {
+		cells := make([]string, len(row))
+		for column, cell := range row {
+			cells[column] = cell.text
+		}
+		writeRow(cells)
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// wikipediaTableRows returns the rows of a table, excluding the rows of
+// nested tables.
+func wikipediaTableRows(table *goquery.Selection) []*goquery.Selection {
+	rows := make([]*goquery.Selection, 0)
+	table.Find("tr").Each(func(_ int, tr *goquery.Selection) {
+		if tr.Closest("table").IsSelection(table) {
+			rows = append(rows, tr)
+		}
+	})
+	return rows
+}
+
+// parseWikipediaTableGrid expands the rows of a table into a grid in which
+// a cell spanning multiple rows or columns occupies each of the positions it
+// covers.
+func parseWikipediaTableGrid(table *colly.HTMLElement, rows []*goquery.Selection) [][]wikipediaTableCell {
+	grid := make([][]wikipediaTableCell, len(rows))
+	filled := make([][]bool, len(rows))
+
+	place := func(row, column int, cell wikipediaTableCell) {
+		for len(grid[row]) <= column {
+			grid[row] = append(grid[row], wikipediaTableCell{})
+			filled[row] = append(filled[row], false)
+		}
+		grid[row][column] = cell
+		filled[row][column] = true
+	}
+
+	for r, tr := range rows {
+		column := 0
+		tr.ChildrenFiltered("th, td").Each(func(i int, td *goquery.Selection) {
+			// skip positions taken by cells spanning from previous rows
+			for column < len(filled[r]) && filled[r][column] {
+				column++
+			}
+
+			element := colly.NewHTMLElementFromSelectionNode(table.Response, td, td.Get(0), i)
+			cell := wikipediaTableCell{
+				text:   escapeWikipediaTableCell(strings.Join(parseWikipediaCell(element), "<br>")),
+				header: td.Is("th"),
+			}
+			rowspan := wikipediaTableSpan(td, "rowspan")
+			colspan := wikipediaTableSpan(td, "colspan")
+			for dr := 0; dr < rowspan && r+dr < len(rows); dr++ {
+				for dc := range colspan {
+					place(r+dr, column+dc, cell)
+				}
+			}
+			column += colspan
+		})
+	}
+
+	return grid
+}
+
+// wikipediaTableSpan returns the value of the rowspan or colspan attribute
+// of a cell, defaulting to 1.
+func wikipediaTableSpan(cell *goquery.Selection, attr string) int {
+	value, err := strconv.Atoi(strings.TrimSpace(cell.AttrOr(attr, "1")))
+	if err != nil || value < 1 {
+		return 1
+	}
+	return min(value, wikipediaMaxSpan)
+}
+
+// wikipediaHTMLTableAttributes contains the attributes kept when a table is
+// rendered as HTML.
+var wikipediaHTMLTableAttributes = map[string]bool{
+	"href":    true,
+	"src":     true,
+	"alt":     true,
+	"colspan": true,
+	"rowspan": true,
+}
+
+// parseWikipediaHTMLTable renders a table as HTML, stripping citations,
+// hidden sort keys, styling and other attributes, and making links and
+// images absolute.
+func parseWikipediaHTMLTable(table *colly.HTMLElement) string {
+	clone := table.DOM.Clone()
+	clone.Find("sup.reference, .mw-editsection, .sortkey, style, [style*='display:none'], [style*='display: none']").Remove()
+
+	for _, root := range clone.Nodes {
+		var clean func(node *html.Node)
+		clean = func(node *html.Node) {
+			if node.Type == html.ElementNode {
+				attrs := make([]html.Attribute, 0, len(node.Attr))
+				for _, attr := range node.Attr {
+					if !wikipediaHTMLTableAttributes[attr.Key] {
+						continue
+					}
+					switch attr.Key {
+					case "href":
+						attr.Val = table.Request.AbsoluteURL(attr.Val)
+					case "src":
+						attr.Val = wikipediaImageURL(table, attr.Val)
+					}
+					attrs = append(attrs, attr)
+				}
+				node.Attr = attrs
+			}
+			for child := node.FirstChild; child != nil; {
+				next := child.NextSibling
+				// whitespace between rows and cells is dropped as blank
+				// lines would end the HTML block in markdown
+				if child.Type == html.TextNode && strings.TrimSpace(child.Data) == "" &&
+					slices.Contains([]string{"table", "thead", "tbody", "tfoot", "tr"}, node.Data) {
+					node.RemoveChild(child)
+				} else {
+					clean(child)
+				}
+				child = next
+			}
+		}
+		clean(root)
+	}
+
+	markup, err := goquery.OuterHtml(clone)
+	if err != nil {
+		return ""
+	}
+	return markup + "\n\n"
+}
+
+// parseWikipediaInfobox extracts the title, images and labelled rows of a
+// table with class "infobox".
+func parseWikipediaInfobox(table *colly.HTMLElement) *WikipediaInfobox {
+	infobox := &WikipediaInfobox{
+		Title: strings.Join(strings.Fields(table.ChildText("caption")), " "),
+	}
+	section := ""
 
 	table.ForEach("tr", func(_ int, tr *colly.HTMLElement) {
-		cells := make([]string, 0)
-		isHeader := false
+		// rows of nested tables are rendered as part of their cell
+		if tr.DOM.Closest("table").Get(0) != table.DOM.Get(0) {
+			return
+		}
 
+		var label, data *colly.HTMLElement
 		tr.ForEach("th, td", func(_ int, cell *colly.HTMLElement) {
-			if cell.Name == "th" {
-				isHeader = true
+			if !cell.DOM.Parent().IsSelection(tr.DOM) {
+				return
+			}
+			if cell.Name == "th" && label == nil && data == nil {
+				label = cell
+				return
+			}
+			if data == nil {
+				data = cell
 			}
-			text := strings.TrimSpace(cell.Text)
-			// Replace newlines within cells with spaces.
-			text = strings.ReplaceAll(text, "\n", " ")
-			cells = append(cells, text)
 		})
 
-		if len(cells) == 0 {
+		switch {
+		case label != nil && data != nil:
+			value := strings.Join(parseWikipediaCell(data), "\n")
+			if value == "" {
+				return
+			}
+			infobox.Fields = append(infobox.Fields, WikipediaInfoboxField{
+				Section: section,
+				Label:   strings.Join(parseWikipediaCell(label), " "),
+				Value:   value,
+			})
+		case label != nil:
+			text := strings.Join(parseWikipediaCell(label), " ")
+			if label.DOM.HasClass("infobox-above") || (infobox.Title == "" && len(infobox.Fields) == 0 && section == "") {
+				if infobox.Title == "" {
+					infobox.Title = text
+				}
+				return
+			}
+			section = text
+		case data != nil:
+			if data.DOM.Find("img").Length() > 0 {
+				infobox.Images = append(infobox.Images, parseWikipediaInfoboxImages(data)...)
+			}
+		}
+	})
+
+	return infobox
+}
+
+// parseWikipediaInfoboxImages extracts the images of an infobox cell. The
+// caption of the cell is attached to the last image.
+func parseWikipediaInfoboxImages(cell *colly.HTMLElement) []WikipediaImage {
+	images := make([]WikipediaImage, 0)
+	cell.ForEach("img", func(_ int, img *colly.HTMLElement) {
+		src := img.Attr("src")
+		if src == "" {
 			return
 		}
+		images = append(images, WikipediaImage{
+			URL: wikipediaImageURL(img, src),
+			Alt: img.Attr("alt"),
+		})
+	})
+	if len(images) == 0 {
+		return images
+	}
+	cell.ForEach("div.infobox-caption", func(_ int, caption *colly.HTMLElement) {
+		images[len(images)-1].Caption = strings.TrimSpace(parseWikipediaInline(caption))
+	})
+	return images
+}
 
-		builder.WriteString("| ")
-		builder.WriteString(strings.Join(cells, " | "))
-		builder.WriteString(" |\n")
+// parseWikipediaCell renders the content of an infobox or table cell as
+// lines of inline markdown. Line breaks, list items and block elements start new
+// lines, and images are rendered as markdown images.
+func parseWikipediaCell(cell *colly.HTMLElement) []string {
+	lines := make([]string, 0)
+	line := strings.Builder{}
+	flush := func() {
+		text := strings.Join(strings.Fields(line.String()), " ")
+		if text != "" {
+			lines = append(lines, text)
+		}
+		line.Reset()
+	}
 
-		if isHeader || rowIndex == 0 {
-			builder.WriteString("|")
-			for range cells {
-				builder.WriteString(" --- |")
+	contents := cell.DOM.Contents()
+	for i, node := range contents.Nodes {
+		if node.Type != html.ElementNode {
+			line.WriteString(parseWikipediaInlineSelection(cell, contents.Eq(i)))
+			continue
+		}
+		sel := contents.Eq(i)
+		switch {
+		case node.Data == "br":
+			flush()
+		case node.Data == "ul", node.Data == "ol", node.Data == "div", node.Data == "p", node.Data == "table":
+			flush()
+			child := colly.NewHTMLElementFromSelectionNode(cell.Response, sel, node, i)
+			if node.Data == "ul" || node.Data == "ol" {
+				child.ForEach("li", func(_ int, li *colly.HTMLElement) {
+					if !li.DOM.Parent().IsSelection(child.DOM) {
+						return
+					}
+					lines = append(lines, parseWikipediaCell(li)...)
+				})
+				continue
 			}
-			builder.WriteString("\n")
+			lines = append(lines, parseWikipediaCell(child)...)
+		case sel.HasClass("mwe-math-element"):
+			line.WriteString(parseWikipediaInlineSelection(cell, sel))
+		case node.Data == "img" || sel.Find("img").Length() > 0:
+			sel.Find("img").AddBack().Filter("img").Each(func(_ int, img *goquery.Selection) {
+				src, _ := img.Attr("src")
+				if src == "" {
+					return
+				}
+				alt, _ := img.Attr("alt")
+				line.WriteString(fmt.Sprintf("![%s](%s)", alt, wikipediaImageURL(cell, src)))
+			})
+		default:
+			line.WriteString(parseWikipediaInlineSelection(cell, sel))
+		}
+	}
+	flush()
+
+	return lines
+}
+
+// markdown renders the infobox as its images followed by a key/value table.
+func (i *WikipediaInfobox) markdown() string {
+	builder := strings.Builder{}
+
+	for _, image := range i.Images {
+		builder.WriteString(fmt.Sprintf("![%s](%s)\n\n", image.Alt, image.URL))
+		if image.Caption != "" {
+			builder.WriteString(fmt.Sprintf("*%s*\n\n", image.Caption))
+		}
+	}
+
+	if len(i.Fields) == 0 {
+		return builder.String()
+	}
+
+	title := i.Title
+	if title == "" {
+		title = "Infobox"
+	}
+	builder.WriteString(fmt.Sprintf("| %s | |\n", escapeWikipediaTableCell(title)))
+	builder.WriteString("| --- | --- |\n")
+	section := ""
+	for _, field := range i.Fields {
+		if field.Section != section {
+			section = field.Section
+			builder.WriteString(fmt.Sprintf("| **%s** | |\n", escapeWikipediaTableCell(section)))
+		}
+		value := strings.ReplaceAll(field.Value, "\n", "<br>")
+		builder.WriteString(fmt.Sprintf("| %s | %s |\n", escapeWikipediaTableCell(field.Label), escapeWikipediaTableCell(value)))
+	}
+	builder.WriteString("\n")
+
+	return builder.String()
+}
+
+// escapeWikipediaTableCell escapes the pipe characters of the content of a
+// markdown table cell.
+func escapeWikipediaTableCell(text string) string {
+	return strings.ReplaceAll(text, "|", "\\|")
+}
+
+// wikipediaCitation is a note cited in an article body.
+type wikipediaCitation struct {
+	// id is the id of the <li> element of the note in the references list
+	id string
+	// label is the label of the markdown footnote
+	label string
+}
+
+// replaceWikipediaCitations replaces the citation superscripts of an article
+// body with markdown footnote markers such as [^1]. It returns the cited
+// notes in the order of their first citation.
+func replaceWikipediaCitations(e *colly.HTMLElement) []wikipediaCitation {
+	citations := make([]wikipediaCitation, 0)
+	labels := make(map[string]string)
+
+	e.DOM.Find("sup.reference").Each(func(_ int, sup *goquery.Selection) {
+		href, _ := sup.Find("a").Attr("href")
+		id, found := strings.CutPrefix(href, "#")
+		if !found || id == "" {
+			return
+		}
+		label, ok := labels[id]
+		if !ok {
+			label = wikipediaFootnoteLabel(sup.Text())
+			labels[id] = label
+			citations = append(citations, wikipediaCitation{id: id, label: label})
 		}
+		sup.ReplaceWithNodes(&html.Node{
+			Type: html.TextNode,
+			Data: fmt.Sprintf("[^%s]", label),
+		})
+	})
 
-		rowIndex++
+	return citations
+}
+
+// wikipediaFootnoteLabel converts the text of a citation superscript such as
+// "[1]" or "[note 1]" into a footnote label such as "1" or "note-1".
+func wikipediaFootnoteLabel(text string) string {
+	text = strings.Trim(strings.TrimSpace(text), "[]")
+	return strings.Join(strings.Fields(text), "-")
+}
+
+// parseWikipediaFootnotes renders the references of the notes cited in
+// content as markdown footnote definitions, preserving the links to the
+// sources.
+func parseWikipediaFootnotes(e *colly.HTMLElement, citations []wikipediaCitation, content string) string {
+	if len(citations) == 0 {
+		return ""
+	}
+
+	definitions := make(map[string]string)
+	e.ForEach("ol.references > li[id]", func(_ int, li *colly.HTMLElement) {
+		note := li
+		li.ForEach("span.reference-text", func(i int, span *colly.HTMLElement) {
+			if i == 0 {
+				note = span
+			}
+		})
+		text := strings.Join(strings.Fields(parseWikipediaInline(note)), " ")
+		if text != "" {
+			definitions[li.Attr("id")] = text
+		}
 	})
 
+	builder := strings.Builder{}
+	for _, citation := range citations {
+		text, ok := definitions[citation.id]
+		// citations of skipped sections are not rendered
+		if !ok || !strings.Contains(content, fmt.Sprintf("[^%s]", citation.label)) {
+			continue
+		}
+		builder.WriteString(fmt.Sprintf("[^%s]: %s\n", citation.label, text))
+	}
+	if builder.Len() == 0 {
+		return ""
+	}
 	builder.WriteString("\n")
 	return builder.String()
 }
+
+// parseWikipediaMath renders a formula (span.mwe-math-element) as LaTeX. The
+// TeX source is taken from the annotation of the hidden MathML, falling back
+// to the alternative text of the formula. Inline formulas are delimited by
+// $ and display formulas by $$ on their own lines.
+func parseWikipediaMath(sel *goquery.Selection) string {
+	tex := strings.TrimSpace(sel.Find("annotation[encoding='application/x-tex']").First().Text())
+	if tex == "" {
+		tex, _ = sel.Find("math").First().Attr("alttext")
+	}
+	if tex == "" {
+		tex, _ = sel.Find("img").First().Attr("alt")
+	}
+	tex = trimWikipediaMathStyle(strings.TrimSpace(tex))
+	if tex == "" {
+		return ""
+	}
+
+	display := sel.HasClass("mwe-math-element-block") ||
+		sel.Find(".mwe-math-mathml-display, math[display=block], img.mwe-math-fallback-image-display").Length() > 0
+	if display {
+		return fmt.Sprintf("\n\n$$\n%s\n$$\n\n", tex)
+	}
+	return fmt.Sprintf("$%s$", tex)
+}
+
+// trimWikipediaMathStyle removes the {\displaystyle ...} or {\textstyle ...}
+// wrapper which MediaWiki adds to the TeX source of formulas.
+func trimWikipediaMathStyle(tex string) string {
+	for _, style := range []string{`{\displaystyle`, `{\textstyle`} {
+		if inner, found := strings.CutPrefix(tex, style); found && strings.HasSuffix(inner, "}") {
+			return strings.TrimSpace(strings.TrimSuffix(inner, "}"))
+		}
+	}
+	return tex
+}
+
+// isWikipediaMathList returns true when a <dl> element is only used to indent
+// formulas, i.e. every <dd> contains a formula and there are no terms.
+func isWikipediaMathList(dl *colly.HTMLElement) bool {
+	if dl.DOM.Find("dt").Length() > 0 {
+		return false
+	}
+	dds := dl.DOM.Find("dd")
+	if dds.Length() == 0 {
+		return false
+	}
+	math := true
+	dds.Each(func(_ int, dd *goquery.Selection) {
+		if dd.Find("span.mwe-math-element").Length() == 0 {
+			math = false
+		}
+	})
+	return math
+}
@@ -0,0 +1,198 @@
+package scraper
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/PuerkitoBio/goquery"
+	"github.com/gocolly/colly"
+)
+
+// mediaWikiAPIPath is the path of the MediaWiki Action API on Wikipedia
+// hosts.
+const mediaWikiAPIPath = "/w/api.php"
+
+// mediaWikiError is the error returned by the MediaWiki Action API.
+type mediaWikiError struct {
+	Code string `json:"code"`
+	Info string `json:"info"`
+}
+
+// mediaWikiParseResponse is the response of action=parse.
+type mediaWikiParseResponse struct {
+	Parse struct {
+		Title      string `json:"title"`
+		RevisionID int64  `json:"revid"`
+		Text       string `json:"text"`
+		Categories []struct {
+			Category string `json:"category"`
+			Hidden   bool   `json:"hidden"`
+		} `json:"categories"`
+		Redirects []struct {
+			From string `json:"from"`
+			To   string `json:"to"`
+		} `json:"redirects"`
+	} `json:"parse"`
+	Error *mediaWikiError `json:"error"`
+}
+
+// mediaWikiRevisionsResponse is the response of action=query&prop=revisions.
+type mediaWikiRevisionsResponse struct {
+	Query struct {
+		Pages []struct {
+			Revisions []struct {
+				Timestamp string `json:"timestamp"`
+			} `json:"revisions"`
+		} `json:"pages"`
+	} `json:"query"`
+	Error *mediaWikiError `json:"error"`
+}
+
+// scrapeAPI fetches the article of the specified URL through the MediaWiki
+// Action API of its wiki. The title of the article is taken from the path
+// (/wiki/Title) or the title parameter of the URL, and the revision from
+// the oldid parameter unless Revision is set.
+func (w *WikipediaScraper) scrapeAPI(pageURL string) (*WikipediaArticle, error) {
+	parsedURL, err := url.Parse(pageURL)
+	if err != nil {
+		return nil, err
+	}
+	title, revision := getWikipediaPage(parsedURL)
+	if w.Revision != 0 {
+		revision = w.Revision
+	}
+	if title == "" && revision == 0 {
+		return nil, fmt.Errorf("unable to find the title of the article from %s", pageURL)
+	}
+
+	params := url.Values{}
+	params.Set("action", "parse")
+	params.Set("prop", "text|revid|categories")
+	params.Set("disableeditsection", "1")
+	if revision != 0 {
+		params.Set("oldid", strconv.FormatInt(revision, 10))
+	} else {
+		params.Set("page", title)
+		params.Set("redirects", "1")
+	}
+	var parsed mediaWikiParseResponse
+	if err := fetchMediaWikiAPI(parsedURL, params, &parsed); err != nil {
+		return nil, err
+	}
+	if parsed.Error != nil {
+		return nil, fmt.Errorf("mediawiki api error %s: %s", parsed.Error.Code, parsed.Error.Info)
+	}
+
+	article := &WikipediaArticle{
+		Title:    parsed.Parse.Title,
+		Revision: parsed.Parse.RevisionID,
+	}
+	for _, category := range parsed.Parse.Categories {
+		// hidden categories are used for maintenance
+		if category.Hidden {
+			continue
+		}
+		article.Categories = append(article.Categories, strings.ReplaceAll(category.Category, "_", " "))
+	}
+	if len(parsed.Parse.Redirects) > 0 {
+		article.RedirectedFrom = parsed.Parse.Redirects[0].From
+	}
+
+	if article.Revision != 0 {
+		params := url.Values{}
+		params.Set("action", "query")
+		params.Set("prop", "revisions")
+		params.Set("rvprop", "timestamp")
+		params.Set("revids", strconv.FormatInt(article.Revision, 10))
+		var revisions mediaWikiRevisionsResponse
+		if err := fetchMediaWikiAPI(parsedURL, params, &revisions); err != nil {
+			return nil, err
+		}
+		if revisions.Error != nil {
+			return nil, fmt.Errorf("mediawiki api error %s: %s", revisions.Error.Code, revisions.Error.Info)
+		}
+		for _, page := range revisions.Query.Pages {
+			for _, revision := range page.Revisions {
+				article.Updated = revision.Timestamp
+			}
+		}
+	}
+
+	doc, err := goquery.NewDocumentFromReader(strings.NewReader(parsed.Parse.Text))
+	if err != nil {
+		return nil, err
+	}
+	body := doc.Find("div.mw-parser-output").First()
+	if body.Length() == 0 {
+		return article, nil
+	}
+
+	// links in the article are resolved against the page of the article
+	articleURL := *parsedURL
+	articleURL.Path = "/wiki/" + strings.ReplaceAll(article.Title, " ", "_")
+	articleURL.RawPath = ""
+	articleURL.RawQuery = ""
+	response := &colly.Response{
+		Request: &colly.Request{URL: &articleURL},
+	}
+	e := colly.NewHTMLElementFromSelectionNode(response, body, body.Get(0), 0)
+
+	language := e.Attr("lang")
+	if language == "" {
+		language = getWikipediaLanguage(pageURL)
+	}
+	w.parseBody(e, language, article)
+
+	return article, nil
+}
+
+// getWikipediaPage returns the title and the revision of the page of a
+// Wikipedia URL such as https://en.wikipedia.org/wiki/Go_(programming_language)
+// or https://en.wikipedia.org/w/index.php?title=Go&oldid=123.
+func getWikipediaPage(pageURL *url.URL) (string, int64) {
+	query := pageURL.Query()
+	title := query.Get("title")
+	if path, found := strings.CutPrefix(pageURL.Path, "/wiki/"); found {
+		title = path
+	}
+	title = strings.ReplaceAll(title, "_", " ")
+
+	revision, err := strconv.ParseInt(query.Get("oldid"), 10, 64)
+	if err != nil {
+		revision = 0
+	}
+	return title, revision
+}
+
+// fetchMediaWikiAPI calls the MediaWiki Action API on the host of the
+// specified URL and decodes the JSON response into v.
+func fetchMediaWikiAPI(pageURL *url.URL, params url.Values, v any) error {
+	params.Set("format", "json")
+	params.Set("formatversion", "2")
+	apiURL := url.URL{
+		Scheme:   pageURL.Scheme,
+		Host:     pageURL.Host,
+		Path:     mediaWikiAPIPath,
+		RawQuery: params.Encode(),
+	}
+
+	collector := colly.NewCollector()
+
+	var body []byte
+	collector.OnResponse(func(r *colly.Response) {
+		body = r.Body
+	})
+
+	err := collector.Visit(apiURL.String())
+	if err != nil {
+		return err
+	}
+
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("unable to decode response of mediawiki api: %w", err)
+	}
+	return nil
+}
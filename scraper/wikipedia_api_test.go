@@ -0,0 +1,239 @@
+package scraper
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+// newMediaWikiAPIServer returns a stand-in of the MediaWiki Action API
+// serving the article "Go (programming language)", to which "Golang"
+// redirects. Revision 100 is the latest revision and 90 an older one.
+func newMediaWikiAPIServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	revisions := map[string]string{
+		"100": `<p><b>Go</b> is a <a href="/wiki/Programming_language" title="Programming language">programming language</a>.<sup id="cite_ref-1" class="reference"><a href="#cite_note-1">[1]</a></sup></p>`,
+		"90":  `<p><b>Go</b> is a language.</p>`,
+	}
+	timestamps := map[string]string{
+		"100": "2024-05-01T12:00:00Z",
+		"90":  "2023-01-01T08:30:00Z",
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/w/api.php" {
+			http.NotFound(w, r)
+			return
+		}
+		query := r.URL.Query()
+		if query.Get("format") != "json" || query.Get("formatversion") != "2" {
+			t.Errorf("unexpected format parameters: %s", r.URL.RawQuery)
+		}
+
+		var response any
+		switch query.Get("action") {
+		case "parse":
+			revision := query.Get("oldid")
+			redirects := []map[string]string{}
+			switch {
+			case revision != "":
+			case query.Get("page") == "Go (programming language)":
+				revision = "100"
+			case query.Get("page") == "Golang" && query.Get("redirects") == "1":
+				revision = "100"
+				redirects = append(redirects, map[string]string{"from": "Golang", "to": "Go (programming language)"})
+			}
+			text, ok := revisions[revision]
+			if !ok {
+				response = map[string]any{
+					"error": map[string]string{"code": "missingtitle", "info": "The page you specified doesn't exist."},
+				}
+				break
+			}
+			revid, _ := json.Number(revision).Int64()
+			response = map[string]any{
+				"parse": map[string]any{
+					"title": "Go (programming language)",
+					"revid": revid,
+					"text":  `<div class="mw-content-ltr mw-parser-output" lang="en" dir="ltr">` + text + `</div>`,
+					"categories": []map[string]any{
+						{"category": "Programming_languages", "hidden": false},
+						{"category": "Articles_with_short_description", "hidden": true},
+						{"category": "Google_software", "hidden": false},
+					},
+					"redirects": redirects,
+				},
+			}
+		case "query":
+			response = map[string]any{
+				"query": map[string]any{
+					"pages": []map[string]any{
+						{"revisions": []map[string]string{{"timestamp": timestamps[query.Get("revids")]}}},
+					},
+				},
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+}
+
+func TestWikipediaScraper_ScrapeArticle_API(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true}
+	result, err := scraper.ScrapeArticle(server.URL + "/wiki/Go_(programming_language)")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "---\n" +
+		"revision: \"100\"\n" +
+		"updated: \"2024-05-01T12:00:00Z\"\n" +
+		"categories:\n" +
+		"  - \"Programming languages\"\n" +
+		"  - \"Google software\"\n" +
+		"---\n\n" +
+		"# Go (programming language)\n\n" +
+		"**Go** is a [programming language](" + server.URL + "/wiki/Programming_language).\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_APIRedirect(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true}
+	data, err := scraper.ScrapeData(server.URL + "/wiki/Golang")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	article := data.(*WikipediaArticle)
+	if article.Title != "Go (programming language)" {
+		t.Errorf("expected title of the redirect target, got %q", article.Title)
+	}
+	if article.RedirectedFrom != "Golang" {
+		t.Errorf("expected redirected_from to be Golang, got %q", article.RedirectedFrom)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_APIRevision(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{Revision: 90}
+	result, err := scraper.ScrapeArticle(server.URL + "/wiki/Go_(programming_language)")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "revision: \"90\"\n") {
+		t.Errorf("expected pinned revision in metadata, got: %q", result)
+	}
+	if !strings.Contains(result, "updated: \"2023-01-01T08:30:00Z\"\n") {
+		t.Errorf("expected timestamp of the pinned revision, got: %q", result)
+	}
+	if !strings.Contains(result, "**Go** is a language.") {
+		t.Errorf("expected content of the pinned revision, got: %q", result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_APIRevisionFromURL(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true}
+	result, err := scraper.ScrapeArticle(server.URL + "/w/index.php?title=Go_(programming_language)&oldid=90")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "**Go** is a language.") {
+		t.Errorf("expected content of the revision in the URL, got: %q", result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_APIFootnotes(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true, Footnotes: true}
+	result, err := scraper.ScrapeArticle(server.URL + "/wiki/Go_(programming_language)")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "language).[^1]") {
+		t.Errorf("expected footnote marker, got: %q", result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_APIMissingPage(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true}
+	_, err := scraper.ScrapeArticle(server.URL + "/wiki/Does_not_exist")
+
+	if err == nil {
+		t.Fatal("expected error for missing page, got nil")
+	}
+	if !strings.Contains(err.Error(), "missingtitle") {
+		t.Errorf("expected error to contain the api error code, got: %v", err)
+	}
+}
+
+func TestWikipediaScraper_ScrapeTitle_API(t *testing.T) {
+	server := newMediaWikiAPIServer(t)
+	defer server.Close()
+
+	scraper := &WikipediaScraper{API: true}
+	title, err := scraper.ScrapeTitle(server.URL + "/wiki/Golang")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if title != "Go (programming language)" {
+		t.Errorf("expected title of the redirect target, got %q", title)
+	}
+}
+
+func TestGetWikipediaPage(t *testing.T) {
+	tests := []struct {
+		url      string
+		title    string
+		revision int64
+	}{
+		{"https://en.wikipedia.org/wiki/Go_(programming_language)", "Go (programming language)", 0},
+		{"https://ja.wikipedia.org/wiki/%E3%83%8F%E3%83%83%E3%82%B7%E3%83%A5%E6%9C%A8", "ハッシュ木", 0},
+		{"https://en.wikipedia.org/w/index.php?title=Merkle_tree&oldid=1234", "Merkle tree", 1234},
+		{"https://en.wikipedia.org/w/index.php?oldid=1234", "", 1234},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			parsed, err := url.Parse(tt.url)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			title, revision := getWikipediaPage(parsed)
+			if title != tt.title || revision != tt.revision {
+				t.Errorf("getWikipediaPage(%q) = (%q, %d), want (%q, %d)", tt.url, title, revision, tt.title, tt.revision)
+			}
+		})
+	}
+}
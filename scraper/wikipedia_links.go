@@ -0,0 +1,229 @@
+package scraper
+
+import (
+	"strings"
+
+	"github.com/gocolly/colly"
+)
+
+// wikipediaNonArticleNamespaces contains the namespaces of pages which are
+// not articles, such as files and help pages, in English and in the
+// languages of the larger editions. All of them are matched on every edition
+// as articles may link to pages of other editions.
+var wikipediaNonArticleNamespaces = []string{
+	"Category",
+	"File",
+	"Help",
+	"Image",
+	"Media",
+	"MediaWiki",
+	"Module",
+	"Portal",
+	"Special",
+	"Talk",
+	"Template",
+	"Template talk",
+	"User",
+	"User talk",
+	"Wikipedia",
+	"Draft",
+	// German
+	"Benutzer",
+	"Bild",
+	"Datei",
+	"Diskussion",
+	"Hilfe",
+	"Kategorie",
+	"Spezial",
+	"Vorlage",
+	// French
+	"Aide",
+	"Catégorie",
+	"Fichier",
+	"Modèle",
+	"Portail",
+	"Spécial",
+	"Utilisateur",
+	// Spanish
+	"Archivo",
+	"Ayuda",
+	"Categoría",
+	"Discusión",
+	"Especial",
+	"Plantilla",
+	"Usuario",
+	// Japanese
+	"カテゴリ",
+	"テンプレート",
+	"ノート",
+	"ファイル",
+	"ヘルプ",
+	"ポータル",
+	"利用者",
+	"特別",
+	// Chinese
+	"分类",
+	"分類",
+	"帮助",
+	"幫助",
+	"文件",
+	"檔案",
+	"模板",
+	"特殊",
+	"用户",
+	"使用者",
+	"讨论",
+	"討論",
+}
+
+// wikipediaNavigationSelector selects the elements of an article body whose
+// links are navigation or references rather than content.
+const wikipediaNavigationSelector = ".navbox, .vertical-navbox, .sidebar, .hatnote, .portalbox, " +
+	".metadata, .ambox, .toc, .mw-editsection, .reflist, .mw-references-wrap, ol.references, " +
+	"sup.reference, .noprint"
+
+// ScrapeLinks scrapes links from the specified URL and returns a map of
+// article title and URL. On a category page, the member pages of the
+// category and, up to CategoryDepth levels, of its subcategories are
+// returned. On other pages, such as "List of ..." pages, the links to
+// articles in the body are returned.
+func (w *WikipediaScraper) ScrapeLinks(url string) (map[string]string, error) {
+	collector := colly.NewCollector()
+
+	links := make(map[string]string)
+	// depth of the category of each visited URL
+	depths := map[string]int{url: 0}
+
+	collector.OnHTML("html", func(e *colly.HTMLElement) {
+		if e.DOM.Find("div#mw-pages, div#mw-subcategories").Length() > 0 {
+			w.parseCategoryLinks(e, depths, links)
+			return
+		}
+
+		language := e.Attr("lang")
+		if language == "" {
+			language = getWikipediaLanguage(e.Request.URL.String())
+		}
+		e.ForEach("div#mw-content-text > div.mw-parser-output", func(_ int, body *colly.HTMLElement) {
+			parseWikipediaListLinks(body, w.sectionFilter(language), links)
+		})
+	})
+
+	err := collector.Visit(url)
+	if err != nil {
+		return nil, err
+	}
+
+	return links, nil
+}
+
+// parseCategoryLinks adds the member pages of a category page to links and
+// visits the next page of the members and, within CategoryDepth, the
+// subcategories.
+func (w *WikipediaScraper) parseCategoryLinks(e *colly.HTMLElement, depths map[string]int, links map[string]string) {
+	depth := depths[e.Request.URL.String()]
+
+	e.ForEach("div#mw-pages li a[href]", func(_ int, a *colly.HTMLElement) {
+		addWikipediaLink(a, links)
+	})
+
+	e.ForEach("div#mw-pages a[href*='pagefrom=']", func(_ int, a *colly.HTMLElement) {
+		next := a.Request.AbsoluteURL(a.Attr("href"))
+		if _, ok := depths[next]; ok {
+			return
+		}
+		depths[next] = depth
+		// errors of a page of members do not fail the others
+		_ = a.Request.Visit(next)
+	})
+
+	if depth >= w.CategoryDepth {
+		return
+	}
+	e.ForEach("div#mw-subcategories a[href^='/wiki/']", func(_ int, a *colly.HTMLElement) {
+		subcategory := a.Request.AbsoluteURL(a.Attr("href"))
+		if _, ok := depths[subcategory]; ok {
+			return
+		}
+		depths[subcategory] = depth + 1
+		_ = a.Request.Visit(subcategory)
+	})
+}
+
+// parseWikipediaListLinks adds the links to articles in the sections of an
+// article body selected by filter to links, excluding navigation boxes and
+// references.
+func parseWikipediaListLinks(e *colly.HTMLElement, filter wikipediaSectionFilter, links map[string]string) {
+	emitting := true
+	skipLevel := 0
+
+	e.ForEach("*", func(_ int, child *colly.HTMLElement) {
+		if !child.DOM.Parent().IsSelection(e.DOM) {
+			return
+		}
+
+		if child.Name == "div" && child.DOM.HasClass("mw-heading") {
+			level := wikipediaHeadingLevel(child)
+			if skipLevel > 0 && level > skipLevel {
+				return
+			}
+			skipLevel = 0
+			emitting = !filter.excludes(extractWikipediaHeadingText(child), level)
+			if !emitting {
+				skipLevel = level
+			}
+			return
+		}
+
+		if !emitting || child.DOM.Is(wikipediaNavigationSelector) {
+			return
+		}
+		// infoboxes summarise rather than list articles
+		if child.Name == "table" && !isWikipediaDataTable(child.DOM) {
+			return
+		}
+
+		child.ForEach("a[href^='/wiki/']", func(_ int, a *colly.HTMLElement) {
+			if a.DOM.Closest(wikipediaNavigationSelector).Length() > 0 {
+				return
+			}
+			addWikipediaLink(a, links)
+		})
+	})
+}
+
+// addWikipediaLink adds a link to an article to links, keyed by the title of
+// the article. Links to pages which are not articles are ignored.
+func addWikipediaLink(a *colly.HTMLElement, links map[string]string) {
+	href := a.Attr("href")
+	if !strings.HasPrefix(href, "/wiki/") {
+		return
+	}
+	// links of images are to their file pages, whatever the language
+	if a.DOM.HasClass("mw-file-description") || a.DOM.Find(".mw-file-element").Length() > 0 {
+		return
+	}
+	title := strings.TrimSpace(a.Attr("title"))
+	if title == "" {
+		title = strings.TrimSpace(a.Text)
+	}
+	if title == "" || isWikipediaNonArticle(title) {
+		return
+	}
+	links[title] = a.Request.AbsoluteURL(href)
+}
+
+// isWikipediaNonArticle returns true when a title belongs to a namespace
+// other than articles, e.g. "File:Example.png".
+func isWikipediaNonArticle(title string) bool {
+	namespace, _, found := strings.Cut(title, ":")
+	if !found {
+		return false
+	}
+	for _, ns := range wikipediaNonArticleNamespaces {
+		if strings.EqualFold(namespace, ns) {
+			return true
+		}
+	}
+	return false
+}
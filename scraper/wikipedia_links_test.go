@@ -0,0 +1,256 @@
+package scraper
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+// newWikipediaCategoryServer returns a server of a category tree:
+// Category:Hash functions has two pages of members and the subcategory
+// Category:Cryptographic hash functions, which has the subcategory
+// Category:SHA-2, which links back to Category:Hash functions.
+func newWikipediaCategoryServer() *httptest.Server {
+	category := func(subcategories, members, next string) string {
+		return fmt.Sprintf(`<!DOCTYPE html>
+<html lang="en">
+<body>
+	<div id="mw-content-text">
+		<div class="mw-parser-output"><p>See also <a href="/wiki/Hash_table" title="Hash table">hash table</a>.</p></div>
+	</div>
+	<div id="mw-subcategories">
+		<div class="mw-category"><div class="mw-category-group"><ul>%s</ul></div></div>
+	</div>
+	<div id="mw-pages">
+		%s
+		<div class="mw-category"><div class="mw-category-group"><ul>%s</ul></div></div>
+	</div>
+</body>
+</html>`, subcategories, next, members)
+	}
+	subcategory := func(name string) string {
+		return fmt.Sprintf(`<li><div class="CategoryTreeItem"><span class="CategoryTreeBullet"></span> <a href="/wiki/Category:%s" title="Category:%s">%s</a></div></li>`, name, name, name)
+	}
+	member := func(name string) string {
+		title := strings.ReplaceAll(name, "_", " ")
+		return fmt.Sprintf(`<li><a href="/wiki/%s" title="%s">%s</a></li>`, name, title, title)
+	}
+
+	pages := map[string]string{
+		"/wiki/Category:Hash_functions": category(
+			subcategory("Cryptographic_hash_functions"),
+			member("MD5")+member("Hash_function"),
+			`<a href="/w/index.php?title=Category:Hash_functions&amp;pagefrom=SHA" title="Category:Hash functions">next page</a>`,
+		),
+		"/w/index.php": category(
+			subcategory("Cryptographic_hash_functions"),
+			member("SipHash"),
+			"",
+		),
+		"/wiki/Category:Cryptographic_hash_functions": category(
+			subcategory("SHA-2"),
+			member("BLAKE2"),
+			"",
+		),
+		"/wiki/Category:SHA-2": category(
+			subcategory("Hash_functions"),
+			member("SHA-256")+member("Talk:SHA-2"),
+			"",
+		),
+	}
+
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		page, ok := pages[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(page))
+	}))
+}
+
+func TestWikipediaScraper_ScrapeLinks_Category(t *testing.T) {
+	server := newWikipediaCategoryServer()
+	defer server.Close()
+
+	tests := []struct {
+		depth    int
+		expected map[string]string
+	}{
+		{
+			depth: 0,
+			expected: map[string]string{
+				"MD5":           server.URL + "/wiki/MD5",
+				"Hash function": server.URL + "/wiki/Hash_function",
+				"SipHash":       server.URL + "/wiki/SipHash",
+			},
+		},
+		{
+			depth: 1,
+			expected: map[string]string{
+				"MD5":           server.URL + "/wiki/MD5",
+				"Hash function": server.URL + "/wiki/Hash_function",
+				"SipHash":       server.URL + "/wiki/SipHash",
+				"BLAKE2":        server.URL + "/wiki/BLAKE2",
+			},
+		},
+		{
+			depth: 5,
+			expected: map[string]string{
+				"MD5":           server.URL + "/wiki/MD5",
+				"Hash function": server.URL + "/wiki/Hash_function",
+				"SipHash":       server.URL + "/wiki/SipHash",
+				"BLAKE2":        server.URL + "/wiki/BLAKE2",
+				"SHA-256":       server.URL + "/wiki/SHA-256",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(fmt.Sprintf("depth %d", tt.depth), func(t *testing.T) {
+			scraper := &WikipediaScraper{CategoryDepth: tt.depth}
+			links, err := scraper.ScrapeLinks(server.URL + "/wiki/Category:Hash_functions")
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(links) != len(tt.expected) {
+				t.Errorf("expected %d links, got %v", len(tt.expected), links)
+			}
+			for title, url := range tt.expected {
+				if links[title] != url {
+					t.Errorf("expected link %q to be %q, got %q", title, url, links[title])
+				}
+			}
+		})
+	}
+}
+
+func TestWikipediaScraper_ScrapeLinks_ListPage(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<div role="note" class="hatnote"><a href="/wiki/List_of_algorithms" title="List of algorithms">List of algorithms</a></div>
+			<table class="sidebar"><tr><td><a href="/wiki/Cryptography" title="Cryptography">Cryptography</a></td></tr></table>
+			<p>This is a list of <a href="/wiki/Hash_function" title="Hash function">hash functions</a>.<sup class="reference"><a href="#cite_note-1">[1]</a></sup></p>
+			<div class="mw-heading mw-heading2"><h2 id="Checksums">Checksums<span class="mw-editsection"><a href="/w/index.php?title=List&amp;action=edit&amp;section=1">edit</a></span></h2></div>
+			<table class="wikitable sortable">
+				<tr><th>Name</th><th>Length</th></tr>
+				<tr><td><a href="/wiki/Adler-32" title="Adler-32">Adler-32</a></td><td>32 bits</td></tr>
+				<tr><td><a href="/w/index.php?title=Xyz&amp;action=edit&amp;redlink=1" class="new" title="Xyz (page does not exist)">Xyz</a></td><td>8 bits</td></tr>
+			</table>
+			<ul>
+				<li><a href="/wiki/Fletcher%27s_checksum" title="Fletcher's checksum">Fletcher</a> <span typeof="mw:File"><a href="/wiki/File:Icon.png" class="mw-file-description"><img src="//upload.wikimedia.org/icon.png"/></a></span></li>
+			</ul>
+			<div class="mw-heading mw-heading2"><h2 id="See_also">See also</h2></div>
+			<ul><li><a href="/wiki/Hash_table" title="Hash table">Hash table</a></li></ul>
+			<div class="mw-heading mw-heading2"><h2 id="References">References</h2></div>
+			<div class="reflist"><ol class="references"><li><a href="/wiki/Knuth" title="Knuth">Knuth</a></li></ol></div>
+			<div class="navbox"><a href="/wiki/Navigation" title="Navigation">Navigation</a></div>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	links, err := scraper.ScrapeLinks(server.URL + "/wiki/List_of_hash_functions")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"Hash function":       server.URL + "/wiki/Hash_function",
+		"Adler-32":            server.URL + "/wiki/Adler-32",
+		"Fletcher's checksum": server.URL + "/wiki/Fletcher%27s_checksum",
+	}
+	if len(links) != len(expected) {
+		t.Errorf("expected %d links, got %v", len(expected), links)
+	}
+	for title, url := range expected {
+		if links[title] != url {
+			t.Errorf("expected link %q to be %q, got %q", title, url, links[title])
+		}
+	}
+}
+
+func TestWikipediaScraper_ScrapeLinks_NonEnglishPage(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="ja">
+<body>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p><a href="/wiki/%E3%83%8D%E3%82%B3" title="ネコ">ネコ</a>は<a href="/wiki/%E5%93%BA%E4%B9%B3%E9%A1%9E" title="哺乳類">哺乳類</a>である。</p>
+			<figure typeof="mw:File/Thumb"><a href="/wiki/%E3%83%95%E3%82%A1%E3%82%A4%E3%83%AB:Cat.jpg" class="mw-file-description"><img src="//upload.wikimedia.org/cat.jpg" class="mw-file-element"/></a></figure>
+			<ul>
+				<li><a href="/wiki/%E3%82%AB%E3%83%86%E3%82%B4%E3%83%AA:%E3%83%8D%E3%82%B3" title="カテゴリ:ネコ">カテゴリ:ネコ</a></li>
+				<li><a href="/wiki/Kategorie:Katze" title="Kategorie:Katze">Kategorie:Katze</a></li>
+				<li><a href="/wiki/Bild:Katze.jpg"><img src="//upload.wikimedia.org/katze.jpg" class="mw-file-element"/></a></li>
+			</ul>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	links, err := scraper.ScrapeLinks(server.URL + "/wiki/%E3%83%8D%E3%82%B3%E3%81%AE%E4%B8%80%E8%A6%A7")
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := map[string]string{
+		"ネコ":  server.URL + "/wiki/%E3%83%8D%E3%82%B3",
+		"哺乳類": server.URL + "/wiki/%E5%93%BA%E4%B9%B3%E9%A1%9E",
+	}
+	if len(links) != len(expected) {
+		t.Errorf("expected %d links, got %v", len(expected), links)
+	}
+	for title, url := range expected {
+		if links[title] != url {
+			t.Errorf("expected link %q to be %q, got %q", title, url, links[title])
+		}
+	}
+}
+
+func TestIsWikipediaNonArticle(t *testing.T) {
+	tests := []struct {
+		title string
+		want  bool
+	}{
+		{"Merkle tree", false},
+		{"Star Wars: A New Hope", false},
+		{"File:Hash Tree.svg", true},
+		{"Category:Hash functions", true},
+		{"Template talk:Cryptography", true},
+		{"Kategorie:Katze", true},
+		{"Catégorie:Chat", true},
+		{"ファイル:Cat.jpg", true},
+		{"カテゴリ:ネコ", true},
+		{"Wikipedia:Über Wikipedia", true},
+	}
+
+	for _, tt := range tests {
+		got := isWikipediaNonArticle(tt.title)
+		if got != tt.want {
+			t.Errorf("isWikipediaNonArticle(%q) = %v, want %v", tt.title, got, tt.want)
+		}
+	}
+}
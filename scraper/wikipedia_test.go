@@ -191,7 +191,7 @@ func TestWikipediaScraper_ScrapeArticle_InternalLink(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if !strings.Contains(result, "[cryptography](https://en.wikipedia.org/wiki/Cryptography)") {
+	if !strings.Contains(result, "[cryptography]("+server.URL+"/wiki/Cryptography)") {
 		t.Errorf("expected internal wiki link, got: %q", result)
 	}
 }
@@ -775,10 +775,10 @@ func TestWikipediaScraper_ScrapeArticle_ListWithLinks(t *testing.T) {
 		t.Fatalf("unexpected error: %v", err)
 	}
 
-	if !strings.Contains(result, "[Bitcoin](https://en.wikipedia.org/wiki/Bitcoin)") {
+	if !strings.Contains(result, "[Bitcoin]("+server.URL+"/wiki/Bitcoin)") {
 		t.Errorf("expected wiki link in list, got: %q", result)
 	}
-	if !strings.Contains(result, "[Git](https://en.wikipedia.org/wiki/Git)") {
+	if !strings.Contains(result, "[Git]("+server.URL+"/wiki/Git)") {
 		t.Errorf("expected wiki link in list, got: %q", result)
 	}
 }
@@ -847,10 +847,10 @@ func TestWikipediaScraper_ScrapeArticle_MixedContent(t *testing.T) {
 	}{
 		{"# Merkle tree", "h1 title"},
 		{"**Merkle tree**", "bold text"},
-		{"[hash](https://en.wikipedia.org/wiki/Hash_function)", "internal link"},
+		{"[hash](" + server.URL + "/wiki/Hash_function)", "internal link"},
 		{"## Overview", "h2 heading"},
 		{"Merkle trees are typically used in distributed systems.", "paragraph"},
-		{"[Bitcoin](https://en.wikipedia.org/wiki/Bitcoin)", "list item link"},
+		{"[Bitcoin](" + server.URL + "/wiki/Bitcoin)", "list item link"},
 		{"![Tree diagram](https://upload.wikimedia.org/", "image"},
 	}
 
@@ -1083,3 +1083,878 @@ func TestWikipediaScraper_ScrapeArticle_ExcludesShortDescription(t *testing.T) {
 		t.Errorf("expected real content, got: %q", result)
 	}
 }
+
+func TestWikipediaScraper_ScrapeArticle_JapaneseSkipSections(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="ja">
+<head><title>マークル木</title></head>
+<body>
+	<h1 id="firstHeading"><span class="mw-page-title-main">マークル木</span></h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p><a href="/wiki/%E3%83%8F%E3%83%83%E3%82%B7%E3%83%A5">ハッシュ</a>を用いた木構造。</p>
+			<div class="mw-heading mw-heading2"><h2 id="概要">概要</h2></div>
+			<p>本文</p>
+			<div class="mw-heading mw-heading2"><h2 id="脚注">脚注</h2></div>
+			<p>脚注の内容</p>
+			<div class="mw-heading mw-heading2"><h2 id="関連項目">関連項目</h2></div>
+			<ul><li>関連項目の内容</li></ul>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "[ハッシュ]("+server.URL+"/wiki/%E3%83%8F%E3%83%83%E3%82%B7%E3%83%A5)") {
+		t.Errorf("expected link on the scraped edition, got: %q", result)
+	}
+	if !strings.Contains(result, "## 概要") || !strings.Contains(result, "本文") {
+		t.Errorf("expected content section, got: %q", result)
+	}
+	for _, skipped := range []string{"脚注", "関連項目"} {
+		if strings.Contains(result, skipped) {
+			t.Errorf("expected section %q to be skipped, got: %q", skipped, result)
+		}
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_SkipSectionsOverride(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="de">
+<head><title>Test</title></head>
+<body>
+	<h1 id="firstHeading"><span class="mw-page-title-main">Test</span></h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>Einleitung</p>
+			<div class="mw-heading mw-heading2"><h2 id="Geschichte">Geschichte</h2></div>
+			<p>Geschichte des Themas</p>
+			<div class="mw-heading mw-heading2"><h2 id="Weblinks">Weblinks</h2></div>
+			<ul><li>Ein Weblink</li></ul>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{
+		SkipSections: map[string][]string{
+			"de": {"Geschichte"},
+		},
+	}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if strings.Contains(result, "Geschichte des Themas") {
+		t.Errorf("expected configured section to be skipped, got: %q", result)
+	}
+	if !strings.Contains(result, "Ein Weblink") {
+		t.Errorf("expected built-in list to be replaced by configuration, got: %q", result)
+	}
+}
+
+func TestWikipediaScraper_SkipSections(t *testing.T) {
+	tests := []struct {
+		name     string
+		language string
+		heading  string
+		want     bool
+	}{
+		{"english", "en", "References", true},
+		{"german", "de", "Einzelnachweise", true},
+		{"french", "fr", "Notes et références", true},
+		{"spanish", "es", "Véase también", true},
+		{"simplified chinese", "zh", "参考文献", true},
+		{"traditional chinese variant", "zh-Hant", "參考文獻", true},
+		{"japanese", "ja", "外部リンク", true},
+		{"unknown language falls back to english", "xx", "External links", true},
+		{"english heading on german edition", "de", "References", false},
+	}
+
+	scraper := &WikipediaScraper{}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := scraper.skipSections(tt.language)[tt.heading]
+			if got != tt.want {
+				t.Errorf("skipSections(%q)[%q] = %v, want %v", tt.language, tt.heading, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestGetWikipediaLanguage(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"https://en.wikipedia.org/wiki/Merkle_tree", "en"},
+		{"https://ja.wikipedia.org/wiki/%E3%83%8F%E3%83%83%E3%82%B7%E3%83%A5%E6%9C%A8", "ja"},
+		{"https://de.m.wikipedia.org/wiki/Hashbaum", "de"},
+		{"https://www.wikipedia.org/", "en"},
+		{"http://127.0.0.1:8080/wiki/Test", "en"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			got := getWikipediaLanguage(tt.url)
+			if got != tt.want {
+				t.Errorf("getWikipediaLanguage(%q) = %q, want %q", tt.url, got, tt.want)
+			}
+		})
+	}
+}
+
+const wikipediaInfoboxHTML = `<!DOCTYPE html>
+<html lang="en">
+<head><title>Go (programming language)</title></head>
+<body>
+	<h1 id="firstHeading"><span class="mw-page-title-main">Go (programming language)</span></h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<table class="infobox vevent">
+				<tbody>
+					<tr><th colspan="2" class="infobox-above">Go</th></tr>
+					<tr><td colspan="2" class="infobox-image"><span typeof="mw:File"><a href="/wiki/File:Go_Logo_Blue.svg"><img src="//upload.wikimedia.org/wikipedia/commons/go.png" alt="Go logo"/></a></span><div class="infobox-caption">Logo of <a href="/wiki/Gopher">the gopher</a></div></td></tr>
+					<tr><th scope="row" class="infobox-label"><a href="/wiki/Programming_paradigm">Paradigm</a></th><td class="infobox-data"><a href="/wiki/Concurrent_computing">Concurrent</a>, <a href="/wiki/Imperative_programming">imperative</a></td></tr>
+					<tr><th scope="row" class="infobox-label">Designed&#160;by</th><td class="infobox-data">Robert Griesemer<br/>Rob Pike<br/>Ken Thompson<sup class="reference"><a href="#cite_note-1">[1]</a></sup></td></tr>
+					<tr><th colspan="2" class="infobox-header">Major implementations</th></tr>
+					<tr><th scope="row" class="infobox-label">Compilers</th><td class="infobox-data"><ul><li>gc</li><li>gccgo | gollvm</li></ul></td></tr>
+				</tbody>
+			</table>
+			<p><b>Go</b> is a programming language.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+func TestWikipediaScraper_ScrapeArticle_Infobox(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wikipediaInfoboxHTML))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Go (programming language)\n\n" +
+		"![Go logo](https://upload.wikimedia.org/wikipedia/commons/go.png)\n\n" +
+		"*Logo of [the gopher](" + server.URL + "/wiki/Gopher)*\n\n" +
+		"| Go | |\n" +
+		"| --- | --- |\n" +
+		"| [Paradigm](" + server.URL + "/wiki/Programming_paradigm) | [Concurrent](" + server.URL + "/wiki/Concurrent_computing), [imperative](" + server.URL + "/wiki/Imperative_programming) |\n" +
+		"| Designed by | Robert Griesemer<br>Rob Pike<br>Ken Thompson |\n" +
+		"| **Major implementations** | |\n" +
+		"| Compilers | gc<br>gccgo \\| gollvm |\n" +
+		"\n" +
+		"**Go** is a programming language.\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeData_Infobox(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wikipediaInfoboxHTML))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	data, err := scraper.ScrapeData(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	article, ok := data.(*WikipediaArticle)
+	if !ok {
+		t.Fatalf("expected *WikipediaArticle, got %T", data)
+	}
+	if article.Title != "Go (programming language)" {
+		t.Errorf("expected title, got %q", article.Title)
+	}
+	if article.Content != "**Go** is a programming language.\n\n" {
+		t.Errorf("expected content without infobox, got %q", article.Content)
+	}
+	if article.Infobox == nil {
+		t.Fatal("expected infobox")
+	}
+	if article.Infobox.Title != "Go" {
+		t.Errorf("expected infobox title, got %q", article.Infobox.Title)
+	}
+	if len(article.Infobox.Images) != 1 || article.Infobox.Images[0].Alt != "Go logo" {
+		t.Errorf("expected infobox image, got %+v", article.Infobox.Images)
+	}
+
+	want := []WikipediaInfoboxField{
+		{Label: "[Paradigm](" + server.URL + "/wiki/Programming_paradigm)", Value: "[Concurrent](" + server.URL + "/wiki/Concurrent_computing), [imperative](" + server.URL + "/wiki/Imperative_programming)"},
+		{Label: "Designed by", Value: "Robert Griesemer\nRob Pike\nKen Thompson"},
+		{Section: "Major implementations", Label: "Compilers", Value: "gc\ngccgo | gollvm"},
+	}
+	if len(article.Infobox.Fields) != len(want) {
+		t.Fatalf("expected %d fields, got %+v", len(want), article.Infobox.Fields)
+	}
+	for i, field := range want {
+		if article.Infobox.Fields[i] != field {
+			t.Errorf("field %d: expected %+v, got %+v", i, field, article.Infobox.Fields[i])
+		}
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_NoInfobox(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>Content</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if result != "# Test\n\nContent\n\n" {
+		t.Errorf("unexpected result: %q", result)
+	}
+}
+
+const wikipediaCitationsHTML = `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Merkle tree</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>Merkle trees were patented in 1979.<sup id="cite_ref-patent_1-0" class="reference"><a href="#cite_note-patent-1"><span class="cite-bracket">[</span>1<span class="cite-bracket">]</span></a></sup></p>
+			<ul>
+				<li>Used in Git<sup id="cite_ref-2" class="reference"><a href="#cite_note-2">[2]</a></sup></li>
+			</ul>
+			<p>They were described again later.<sup id="cite_ref-patent_1-1" class="reference"><a href="#cite_note-patent-1">[1]</a></sup><sup class="noprint Inline-Template Template-Fact">[<i><a href="/wiki/Wikipedia:Citation_needed">citation needed</a></i>]</sup></p>
+			<p>A side note.<sup id="cite_ref-3" class="reference"><a href="#cite_note-note-a-3">[note a]</a></sup></p>
+			<div class="mw-heading mw-heading2"><h2 id="References">References</h2></div>
+			<div class="reflist">
+				<div class="mw-references-wrap">
+					<ol class="references">
+						<li id="cite_note-patent-1"><span class="mw-cite-backlink">^ <a href="#cite_ref-patent_1-0"><sup><i><b>a</b></i></sup></a> <a href="#cite_ref-patent_1-1"><sup><i><b>b</b></i></sup></a></span> <span class="reference-text"><cite class="citation patent">Merkle, R. C. <a rel="nofollow" class="external text" href="https://patents.google.com/patent/US4309569">"Method of providing digital signatures"</a>. 1982.</cite></span></li>
+						<li id="cite_note-2"><span class="mw-cite-backlink"><b><a href="#cite_ref-2">^</a></b></span> <span class="reference-text"><a rel="nofollow" class="external text" href="https://git-scm.com/">Git</a> documentation.</span></li>
+						<li id="cite_note-note-a-3"><span class="mw-cite-backlink"><b><a href="#cite_ref-3">^</a></b></span> <span class="reference-text">An <i>explanatory</i> note.</span></li>
+						<li id="cite_note-4"><span class="reference-text">Never cited.</span></li>
+					</ol>
+				</div>
+			</div>
+		</div>
+	</div>
+</body>
+</html>`
+
+func TestWikipediaScraper_ScrapeArticle_Footnotes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wikipediaCitationsHTML))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{Footnotes: true}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Merkle tree\n\n" +
+		"Merkle trees were patented in 1979.[^1]\n\n" +
+		"* Used in Git[^2]\n\n" +
+		"They were described again later.[^1]\n\n" +
+		"A side note.[^note-a]\n\n" +
+		"[^1]: Merkle, R. C. [\"Method of providing digital signatures\"](https://patents.google.com/patent/US4309569). 1982.\n" +
+		"[^2]: [Git](https://git-scm.com/) documentation.\n" +
+		"[^note-a]: An *explanatory* note.\n" +
+		"\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_CitationsRemovedByDefault(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wikipediaCitationsHTML))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, unexpected := range []string{"[^", "[1]", "patents.google.com"} {
+		if strings.Contains(result, unexpected) {
+			t.Errorf("expected %q not to be in result, got: %q", unexpected, result)
+		}
+	}
+}
+
+func TestWikipediaFootnoteLabel(t *testing.T) {
+	tests := []struct {
+		text string
+		want string
+	}{
+		{"[1]", "1"},
+		{" [12] ", "12"},
+		{"[note 1]", "note-1"},
+		{"[a]", "a"},
+	}
+
+	for _, tt := range tests {
+		got := wikipediaFootnoteLabel(tt.text)
+		if got != tt.want {
+			t.Errorf("wikipediaFootnoteLabel(%q) = %q, want %q", tt.text, got, tt.want)
+		}
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_Math(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Pythagorean theorem</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>The sides <span class="mwe-math-element"><span class="mwe-math-mathml-inline mwe-math-mathml-a11y" style="display: none;"><math xmlns="http://www.w3.org/1998/Math/MathML" alttext="{\displaystyle a}"><semantics><mrow><mi>a</mi></mrow><annotation encoding="application/x-tex">{\displaystyle a}</annotation></semantics></math></span><img src="https://wikimedia.org/api/rest_v1/media/math/render/svg/a" class="mwe-math-fallback-image-inline" alt="{\displaystyle a}"/></span> and <span class="mwe-math-element"><img src="https://wikimedia.org/api/rest_v1/media/math/render/svg/b" class="mwe-math-fallback-image-inline" alt="{\displaystyle b}"/></span> satisfy</p>
+			<dl><dd><span class="mwe-math-element mwe-math-element-block"><span class="mwe-math-mathml-display mwe-math-mathml-a11y" style="display: none;"><math xmlns="http://www.w3.org/1998/Math/MathML" display="block"><semantics><mrow><msup><mi>a</mi><mn>2</mn></msup></mrow><annotation encoding="application/x-tex">{\displaystyle a^{2}+b^{2}=c^{2}}</annotation></semantics></math></span><img src="https://wikimedia.org/api/rest_v1/media/math/render/svg/c" class="mwe-math-fallback-image-display" alt="{\displaystyle a^{2}+b^{2}=c^{2}}"/></span></dd></dl>
+			<p>where <span class="mwe-math-element"><span class="mwe-math-mathml-inline mwe-math-mathml-a11y"><math alttext="{\textstyle c}"><semantics><mi>c</mi><annotation encoding="application/x-tex">{\textstyle c}</annotation></semantics></math></span></span> is the hypotenuse.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Pythagorean theorem\n\n" +
+		"The sides $a$ and $b$ satisfy\n\n" +
+		"$$\na^{2}+b^{2}=c^{2}\n$$\n\n" +
+		"where $c$ is the hypotenuse.\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_DisplayMathInParagraph(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>It is defined as <span class="mwe-math-element mwe-math-element-block"><span class="mwe-math-mathml-display"><math display="block"><semantics><mi>x</mi><annotation encoding="application/x-tex">{\displaystyle f(x)=x^{2}}</annotation></semantics></math></span></span> for all reals.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if !strings.Contains(result, "It is defined as \n\n$$\nf(x)=x^{2}\n$$\n\n for all reals.") {
+		t.Errorf("expected display formula on its own lines, got: %q", result)
+	}
+}
+
+func TestTrimWikipediaMathStyle(t *testing.T) {
+	tests := []struct {
+		tex  string
+		want string
+	}{
+		{`{\displaystyle x^{2}}`, `x^{2}`},
+		{`{\textstyle \frac{1}{2}}`, `\frac{1}{2}`},
+		{`x + y`, `x + y`},
+	}
+
+	for _, tt := range tests {
+		got := trimWikipediaMathStyle(tt.tex)
+		if got != tt.want {
+			t.Errorf("trimWikipediaMathStyle(%q) = %q, want %q", tt.tex, got, tt.want)
+		}
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_TableSpans(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<table class="wikitable sortable">
+				<caption>Releases of <a href="/wiki/Go_(programming_language)">Go</a></caption>
+				<tbody>
+					<tr><th rowspan="2">Version</th><th colspan="2">Date</th></tr>
+					<tr><th>Year</th><th>Month</th></tr>
+					<tr><td><span class="sortkey" style="display:none">01.00</span><b>1.0</b><sup class="reference"><a href="#cite_note-1">[1]</a></sup></td><td rowspan="2">2012</td><td>March</td></tr>
+					<tr><td>1.1</td><td>May</td></tr>
+					<tr><td colspan="2">Go 2 | drafts</td><td><a href="/wiki/Generics">Generics</a><br/>Errors</td></tr>
+				</tbody>
+			</table>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Test\n\n" +
+		"*Releases of [Go](" + server.URL + "/wiki/Go_(programming_language))*\n\n" +
+		"| Version | Date – Year | Date – Month |\n" +
+		"| --- | --- | --- |\n" +
+		"| **1.0** | 2012 | March |\n" +
+		"| 1.1 | 2012 | May |\n" +
+		"| Go 2 \\| drafts | Go 2 \\| drafts | [Generics](" + server.URL + "/wiki/Generics)<br>Errors |\n" +
+		"\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_NonWikitable(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<table class="box-Unreferenced ambox"><tr><td>This article needs references.</td></tr></table>
+			<table class="sidebar"><tr><td>Series on hashing</td></tr></table>
+			<table>
+				<tr><td>Name</td><td>Size</td></tr>
+				<tr><td>SHA-1</td><td>160</td></tr>
+			</table>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Test\n\n" +
+		"| Name | Size |\n" +
+		"| --- | --- |\n" +
+		"| SHA-1 | 160 |\n" +
+		"\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_NestedTableAsHTML(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<table class="wikitable" style="width:100%">
+				<tr><th>Name</th><th>Details</th></tr>
+				<tr><td><a href="/wiki/SHA-2">SHA-2</a><sup class="reference"><a href="#cite_note-1">[1]</a></sup></td><td><table><tr><td style="color:red">256</td></tr></table></td></tr>
+			</table>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Test\n\n" +
+		"<table><tbody><tr><th>Name</th><th>Details</th></tr>" +
+		"<tr><td><a href=\"" + server.URL + "/wiki/SHA-2\">SHA-2</a></td><td><table><tbody><tr><td>256</td></tr></tbody></table></td></tr>" +
+		"</tbody></table>\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+const wikipediaSectionsHTML = `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Go</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<p>Lead paragraph.</p>
+			<div class="mw-heading mw-heading2"><h2 id="History">History</h2></div>
+			<p>History paragraph.</p>
+			<div class="mw-heading mw-heading3"><h3 id="Origins">Origins</h3></div>
+			<p>Origins paragraph.</p>
+			<div class="mw-heading mw-heading4"><h4 id="Naming">Naming</h4></div>
+			<p>Naming paragraph.</p>
+			<div class="mw-heading mw-heading3"><h3 id="Releases">Releases</h3></div>
+			<p>Releases paragraph.</p>
+			<div class="mw-heading mw-heading2"><h2 id="Design">Design</h2></div>
+			<p>Design paragraph.</p>
+			<div class="mw-heading mw-heading3"><h3 id="Syntax">Syntax</h3></div>
+			<p>Syntax paragraph.</p>
+			<div class="mw-heading mw-heading2"><h2 id="See_also">See also</h2></div>
+			<p>See also paragraph.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+func TestWikipediaScraper_ScrapeArticle_SectionSelection(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(wikipediaSectionsHTML))
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name     string
+		scraper  *WikipediaScraper
+		expected string
+	}{
+		{
+			name:    "default",
+			scraper: &WikipediaScraper{},
+			expected: "Lead paragraph.\n\n## History\n\nHistory paragraph.\n\n### Origins\n\nOrigins paragraph.\n\n" +
+				"#### Naming\n\nNaming paragraph.\n\n### Releases\n\nReleases paragraph.\n\n" +
+				"## Design\n\nDesign paragraph.\n\n### Syntax\n\nSyntax paragraph.\n\n",
+		},
+		{
+			name:     "lead only",
+			scraper:  &WikipediaScraper{LeadOnly: true},
+			expected: "Lead paragraph.\n\n",
+		},
+		{
+			name:    "included sections",
+			scraper: &WikipediaScraper{Sections: []string{"history", "Syntax"}},
+			expected: "Lead paragraph.\n\n## History\n\nHistory paragraph.\n\n### Origins\n\nOrigins paragraph.\n\n" +
+				"#### Naming\n\nNaming paragraph.\n\n### Releases\n\nReleases paragraph.\n\n" +
+				"## Design\n\n### Syntax\n\nSyntax paragraph.\n\n",
+		},
+		{
+			name:    "included subsection",
+			scraper: &WikipediaScraper{Sections: []string{"Naming"}},
+			expected: "Lead paragraph.\n\n## History\n\n### Origins\n\n" +
+				"#### Naming\n\nNaming paragraph.\n\n",
+		},
+		{
+			name:     "included section skipped by default",
+			scraper:  &WikipediaScraper{Sections: []string{"See also"}},
+			expected: "Lead paragraph.\n\n## See also\n\nSee also paragraph.\n\n",
+		},
+		{
+			name:    "excluded sections",
+			scraper: &WikipediaScraper{ExcludeSections: []string{"Origins", "design"}},
+			expected: "Lead paragraph.\n\n## History\n\nHistory paragraph.\n\n" +
+				"### Releases\n\nReleases paragraph.\n\n",
+		},
+		{
+			name:    "maximum heading level",
+			scraper: &WikipediaScraper{MaxHeadingLevel: 2},
+			expected: "Lead paragraph.\n\n## History\n\nHistory paragraph.\n\n" +
+				"## Design\n\nDesign paragraph.\n\n",
+		},
+		{
+			name:    "included section with maximum heading level",
+			scraper: &WikipediaScraper{Sections: []string{"History"}, MaxHeadingLevel: 3},
+			expected: "Lead paragraph.\n\n## History\n\nHistory paragraph.\n\n### Origins\n\nOrigins paragraph.\n\n" +
+				"### Releases\n\nReleases paragraph.\n\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := tt.scraper.ScrapeArticle(server.URL)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := "# Go\n\n" + tt.expected
+			if result != expected {
+				t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+			}
+		})
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_SectionSelectionDuplicatedHeading(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html>
+<body>
+	<h1 id="firstHeading">Go</h1>
+	<div id="mw-content-text">
+		<div class="mw-content-ltr mw-parser-output" lang="en" dir="ltr">
+			<p>Lead paragraph.</p>
+			<div class="mw-heading mw-heading2"><h2 id="History">History</h2></div>
+			<p>H</p>
+			<div class="mw-heading mw-heading3"><h3 id="Early">Early</h3></div>
+			<p>E</p>
+			<div class="mw-heading mw-heading2"><h2 id="Design">Design</h2></div>
+			<p>D</p>
+			<div class="mw-heading mw-heading3"><h3 id="History_2">History</h3></div>
+			<p>DH</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{Sections: []string{"History"}}
+	result, err := scraper.ScrapeArticle(server.URL)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Go\n\nLead paragraph.\n\n## History\n\nH\n\n### Early\n\nE\n\n" +
+		"## Design\n\n### History\n\nDH\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_NestedLists(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<ul>
+				<li>Hash functions
+					<ul>
+						<li><a href="/wiki/SHA-2">SHA-2</a>
+							<ol>
+								<li>SHA-256</li>
+								<li>SHA-512</li>
+							</ol>
+						</li>
+						<li>MD5</li>
+					</ul>
+				</li>
+				<li>Trees</li>
+			</ul>
+			<ol>
+				<li>First
+					<ul><li>Detail</li></ul>
+				</li>
+			</ol>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Test\n\n" +
+		"* Hash functions\n" +
+		"  * [SHA-2](" + server.URL + "/wiki/SHA-2)\n" +
+		"    1. SHA-256\n" +
+		"    2. SHA-512\n" +
+		"  * MD5\n" +
+		"* Trees\n" +
+		"\n" +
+		"1. First\n" +
+		"   * Detail\n" +
+		"\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_DefinitionLists(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Test</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<dl>
+				<dt><a href="/wiki/Leaf">Leaf</a></dt>
+				<dd>A node without children.</dd>
+				<dt>Root</dt>
+				<dd>The top node.</dd>
+				<dd>It has no parent.
+					<ul><li>Unique</li></ul>
+				</dd>
+			</dl>
+			<dl><dd>An indented remark.</dd></dl>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Test\n\n" +
+		"**[Leaf](" + server.URL + "/wiki/Leaf)**\n" +
+		": A node without children.\n" +
+		"\n" +
+		"**Root**\n" +
+		": The top node.\n" +
+		": It has no parent.\n" +
+		"  * Unique\n" +
+		"\n" +
+		"An indented remark.\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}
+
+func TestWikipediaScraper_ScrapeArticle_Hatnote(t *testing.T) {
+	html := `<!DOCTYPE html>
+<html lang="en">
+<body>
+	<h1 id="firstHeading">Go</h1>
+	<div id="mw-content-text">
+		<div class="mw-parser-output">
+			<div role="note" class="hatnote navigation-not-searchable">For the board game, see <a href="/wiki/Go_(game)">Go (game)</a>.</div>
+			<p>Go is a programming language.</p>
+			<div class="mw-heading mw-heading2"><h2 id="History">History</h2></div>
+			<div role="note" class="hatnote navigation-not-searchable">Main article: <a href="/wiki/History_of_Go">History of Go</a></div>
+			<p>History paragraph.</p>
+		</div>
+	</div>
+</body>
+</html>`
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(html))
+	}))
+	defer server.Close()
+
+	scraper := &WikipediaScraper{}
+	result, err := scraper.ScrapeArticle(server.URL)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	expected := "# Go\n\n" +
+		"*For the board game, see [Go (game)](" + server.URL + "/wiki/Go_(game)).*\n\n" +
+		"Go is a programming language.\n\n" +
+		"## History\n\n" +
+		"*Main article: [History of Go](" + server.URL + "/wiki/History_of_Go)*\n\n" +
+		"History paragraph.\n\n"
+	if result != expected {
+		t.Errorf("expected:\n%q\ngot:\n%q", expected, result)
+	}
+}